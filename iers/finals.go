@@ -0,0 +1,103 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Record holds the Earth orientation parameters of a day.
+type Record struct {
+	// Modified julian day (0h UTC).
+	MJD float64
+
+	// Coordinates of the pole in arcseconds.
+	X, Y float64
+
+	// UT1 - UTC in seconds.
+	UT1MinusUTC float64
+
+	// Predicted is set for values that are predictions rather than
+	// measurements.
+	Predicted bool
+}
+
+// Returns the trimmed text between the 1-based columns from and to of line.
+func column(line string, from, to int) string {
+	if len(line) < to {
+		return ""
+	}
+
+	return strings.TrimSpace(line[from-1 : to])
+}
+
+// ReadFinals reads the Bulletin A values of a file in the IERS finals2000A
+// format (finals2000A.all, finals2000A.data or finals2000A.daily), returning
+// the days that have both polar motion and UT1 - UTC, sorted by MJD.
+func ReadFinals(r io.Reader) ([]Record, error) {
+	var rs []Record
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		mjd := column(line, 8, 15)
+		x := column(line, 19, 27)
+		y := column(line, 38, 46)
+		dut1 := column(line, 59, 68)
+		if mjd == "" {
+			return nil, fmt.Errorf("%w: line %d", ErrMalformed, n)
+		}
+
+		// The tail of the file has days without predictions yet.
+		if x == "" || y == "" || dut1 == "" {
+			continue
+		}
+
+		var rec Record
+		var err error
+		for _, f := range []struct {
+			s string
+			v *float64
+		}{
+			{mjd, &rec.MJD}, {x, &rec.X}, {y, &rec.Y}, {dut1, &rec.UT1MinusUTC},
+		} {
+			*f.v, err = strconv.ParseFloat(f.s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, n, err)
+			}
+		}
+		rec.Predicted = column(line, 58, 58) == "P"
+
+		rs = append(rs, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].MJD < rs[j].MJD
+	})
+
+	return rs, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iers
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ReadFinals tests. The sample file holds made up values in the finals2000A
+// layout around the leap second at the end of 2016.
+func TestReadFinals(t *testing.T) {
+	f, err := os.Open("testdata/finals2000A.sample")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	rs, err := ReadFinals(f)
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{
+		{MJD: 57752, X: 0.0421, Y: 0.2805, UT1MinusUTC: -0.4088},
+		{MJD: 57753, X: 0.0435, Y: 0.2791, UT1MinusUTC: -0.4094},
+		{MJD: 57754, X: 0.0449, Y: 0.2777, UT1MinusUTC: 0.5912},
+		{MJD: 57755, X: 0.0463, Y: 0.2763, UT1MinusUTC: 0.5906},
+		{MJD: 57756, X: 0.0477, Y: 0.2749, UT1MinusUTC: 0.59, Predicted: true},
+	}, rs)
+}
+
+// ReadFinals malformed input tests.
+func TestReadFinalsMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"MissingMJD", "161230\n"},
+		{"BadNumber", "161230 57752.00 I  0.04x100 0.000091  0.280500 0.000091  I-0.4088000 0.0000101\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadFinals(strings.NewReader(tt.file))
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
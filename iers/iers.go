@@ -0,0 +1,170 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package iers reads the Earth orientation parameters and leap seconds
+// published by the International Earth Rotation and Reference Systems Service
+// (IERS), for use by the julian time scale conversions and sidereal time.
+//
+// Only leap seconds are built in, from the table of the julian package. The
+// Earth orientation parameters change every week and have to be downloaded from
+// the IERS and loaded by the caller; TimeScales then hands them to julian and
+// solarposition. Without them UT1 is taken to be UTC, which is good to within
+// 0.9 s, and polar motion is left out.
+package iers
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+var (
+	ErrMalformed = errors.New("malformed IERS file")
+)
+
+const (
+	// Julian day of MJD 0.
+	JMJD0 = 2400000.5
+)
+
+// Data holds leap seconds and Earth orientation parameters.
+type Data struct {
+	// LeapSeconds is the table of leap seconds, sorted by time.
+	LeapSeconds []julian.LeapSecond
+
+	// Expires is the time after which the table of leap seconds may be
+	// missing announced leap seconds.
+	Expires time.Time
+
+	// EOP holds the daily Earth orientation parameters, sorted by MJD.
+	EOP []Record
+}
+
+// Bundled returns the built-in table of leap seconds, julian.LeapSeconds. It
+// has no Earth orientation parameters, so its UT1 - UTC is always 0 and it has
+// no polar motion; use Load with a finals2000A file for them.
+func Bundled() (*Data, error) {
+	ls := append([]julian.LeapSecond(nil), julian.LeapSeconds...)
+
+	return &Data{LeapSeconds: ls, Expires: julian.LeapSecondsExpire}, nil
+}
+
+// Load reads a leap-seconds.list file and a finals2000A file from disk. The
+// built-in table of leap seconds is used when leapSecondsPath is empty, and no
+// Earth orientation parameters are loaded when finalsPath is empty.
+func Load(leapSecondsPath, finalsPath string) (*Data, error) {
+	d, err := Bundled()
+	if err != nil {
+		return nil, err
+	}
+
+	if leapSecondsPath != "" {
+		f, err := os.Open(leapSecondsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		d.LeapSeconds, d.Expires, err = ReadLeapSeconds(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if finalsPath != "" {
+		f, err := os.Open(finalsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		d.EOP, err = ReadFinals(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// Returns the records either side of t and how far t is between them, or false
+// when t is outside the table.
+func (d *Data) bracket(t time.Time) (Record, Record, float64, bool) {
+	mjd := julian.ToJulianDay(t.UTC()) - JMJD0
+	if len(d.EOP) < 2 || mjd < d.EOP[0].MJD || mjd > d.EOP[len(d.EOP)-1].MJD {
+		return Record{}, Record{}, 0, false
+	}
+
+	i := sort.Search(len(d.EOP), func(i int) bool {
+		return d.EOP[i].MJD > mjd
+	})
+	if i == len(d.EOP) {
+		i--
+	}
+
+	r0, r1 := d.EOP[i-1], d.EOP[i]
+
+	return r0, r1, (mjd - r0.MJD) / (r1.MJD - r0.MJD), true
+}
+
+// UT1MinusUTC returns UT1 - UTC in seconds at a UTC time, interpolated linearly
+// between the daily values, and false when t is outside the table.
+func (d *Data) UT1MinusUTC(t time.Time) (float64, bool) {
+	r0, r1, f, ok := d.bracket(t)
+	if !ok {
+		return 0, false
+	}
+
+	// A leap second between the records makes UT1 - UTC jump by a second,
+	// which must not be spread over the day before it.
+	v1 := r1.UT1MinusUTC
+	switch diff := v1 - r0.UT1MinusUTC; {
+	case diff > 0.5:
+		v1--
+	case diff < -0.5:
+		v1++
+	}
+
+	return r0.UT1MinusUTC + f*(v1-r0.UT1MinusUTC), true
+}
+
+// PolarMotion returns the coordinates x and y of the pole in arcseconds at a
+// UTC time, interpolated linearly between the daily values, and false when t is
+// outside the table.
+func (d *Data) PolarMotion(t time.Time) (float64, float64, bool) {
+	r0, r1, f, ok := d.bracket(t)
+	if !ok {
+		return 0, 0, false
+	}
+
+	x := r0.X + f*(r1.X-r0.X)
+	y := r0.Y + f*(r1.Y-r0.Y)
+
+	return x, y, true
+}
+
+// TimeScales returns time scale conversions using the leap seconds, UT1 - UTC
+// and polar motion values of the data. Their julian days in UT1 are the ones to
+// pass to the sidereal time and the solar position functions, which take UT1 as
+// given, and a solarposition.Sky given them applies the polar motion.
+func (d *Data) TimeScales() julian.TimeScales {
+	return julian.TimeScales{
+		LeapSeconds: d.LeapSeconds,
+		UT1MinusUTC: d.UT1MinusUTC,
+		PolarMotion: d.PolarMotion,
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// UT1MinusUTC tests, including interpolation across a leap second.
+func TestUT1MinusUTC(t *testing.T) {
+	d, err := Load("", "testdata/finals2000A.sample")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		dut1 float64
+		ok   bool
+	}{
+		{"OnRecord", time.Date(2016, 12, 30, 0, 0, 0, 0, time.UTC), -0.4088, true},
+		{"BeforeLeapSecond", time.Date(2016, 12, 31, 12, 0, 0, 0, time.UTC), -0.4091, true},
+		{"AfterLeapSecond", time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC), 0.5909, true},
+		{"BeforeTable", time.Date(2016, 12, 1, 0, 0, 0, 0, time.UTC), 0, false},
+		{"AfterTable", time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dut1, ok := d.UT1MinusUTC(tt.t)
+			assert.InDelta(t, tt.dut1, dut1, 1e-9)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+// PolarMotion tests.
+func TestPolarMotion(t *testing.T) {
+	d, err := Load("", "testdata/finals2000A.sample")
+	assert.NoError(t, err)
+
+	x, y, ok := d.PolarMotion(time.Date(2017, 1, 2, 6, 0, 0, 0, time.UTC))
+	assert.InDelta(t, 0.04665, x, 1e-9)
+	assert.InDelta(t, 0.27595, y, 1e-9)
+	assert.True(t, ok)
+
+	_, _, ok = d.PolarMotion(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+// TimeScales tests.
+func TestTimeScales(t *testing.T) {
+	d, err := Load("", "testdata/finals2000A.sample")
+	assert.NoError(t, err)
+
+	ts := d.TimeScales()
+	dt := ts.DeltaT(time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.InDelta(t, 32.184+37-0.5906, dt, 1e-9)
+
+	x, y := ts.Pole(time.Date(2017, 1, 2, 6, 0, 0, 0, time.UTC))
+	assert.InDelta(t, 0.04665, x, 1e-9)
+	assert.InDelta(t, 0.27595, y, 1e-9)
+}
+
+// Load tests.
+func TestLoad(t *testing.T) {
+	_, err := Load("testdata/missing.list", "")
+	assert.Error(t, err)
+
+	_, err = Load("", "testdata/missing.data")
+	assert.Error(t, err)
+
+	d, err := Load("testdata/leap-seconds.list", "")
+	assert.NoError(t, err)
+	assert.Len(t, d.LeapSeconds, 28)
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+// Epoch of the NTP timestamps used by leap-seconds.list.
+var ntpEpoch = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Transforms an NTP timestamp into a UTC time.
+func fromNTP(s string) (time.Time, error) {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return ntpEpoch.Add(time.Duration(secs) * time.Second), nil
+}
+
+// ReadLeapSeconds reads a table of leap seconds in the format of the IERS
+// leap-seconds.list file, returning it sorted by time along with the time the
+// file expires (zero when the file does not say).
+func ReadLeapSeconds(r io.Reader) ([]julian.LeapSecond, time.Time, error) {
+	var ls []julian.LeapSecond
+	var expires time.Time
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+
+		if v, ok := strings.CutPrefix(line, "#@"); ok {
+			t, err := fromNTP(strings.TrimSpace(v))
+			if err != nil {
+				return nil, time.Time{}, fmt.Errorf("%w: line %d: %v", ErrMalformed, n, err)
+			}
+			expires = t
+			continue
+		}
+
+		// Drop comments, including the trailing ones naming the date.
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, time.Time{}, fmt.Errorf("%w: line %d", ErrMalformed, n)
+		}
+
+		t, err := fromNTP(fields[0])
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%w: line %d: %v", ErrMalformed, n, err)
+		}
+
+		offset, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("%w: line %d: %v", ErrMalformed, n, err)
+		}
+
+		ls = append(ls, julian.LeapSecond{Time: t, Offset: offset})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if len(ls) == 0 {
+		return nil, time.Time{}, fmt.Errorf("%w: no leap seconds", ErrMalformed)
+	}
+
+	sort.Slice(ls, func(i, j int) bool {
+		return ls[i].Time.Before(ls[j].Time)
+	})
+
+	return ls, expires, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iers
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/stretchr/testify/assert"
+)
+
+// ReadLeapSeconds tests.
+func TestReadLeapSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		ls      []julian.LeapSecond
+		expires time.Time
+		err     error
+	}{
+		{
+			"Valid",
+			"#@\t3660595200\n# comment\n3644697600\t36\t# 1 Jul 2015\n2272060800\t10\t# 1 Jan 1972\n",
+			[]julian.LeapSecond{
+				{Time: time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), Offset: 10},
+				{Time: time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC), Offset: 36},
+			},
+			time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+			nil,
+		},
+		{"Empty", "# nothing here\n", nil, time.Time{}, ErrMalformed},
+		{"BadOffset", "2272060800\tten\n", nil, time.Time{}, ErrMalformed},
+		{"ExtraField", "2272060800\t10\t11\n", nil, time.Time{}, ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls, expires, err := ReadLeapSeconds(strings.NewReader(tt.file))
+			assert.Equal(t, tt.ls, ls)
+			assert.Equal(t, tt.expires, expires)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+// The built-in table of the julian package must match the IERS file.
+func TestBundledLeapSeconds(t *testing.T) {
+	f, err := os.Open("testdata/leap-seconds.list")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	ls, expires, err := ReadLeapSeconds(f)
+	assert.NoError(t, err)
+
+	d, err := Bundled()
+	assert.NoError(t, err)
+	assert.Equal(t, ls, d.LeapSeconds)
+	assert.Equal(t, expires, d.Expires)
+	assert.Empty(t, d.EOP)
+}
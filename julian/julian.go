@@ -21,6 +21,7 @@ import (
 
 const (
 	J2000         = 2451545.0
+	JUnixEpoch    = 2440587.5
 	SecondsPerDay = 86400.0
 	MinutesPerDay = 1440.0
 	HoursPerDay   = 24.0
@@ -78,7 +79,7 @@ func ToJulianDay(t time.Time) float64 {
 	D := t.Day() - 32075
 	H := float64(t.Hour()-12) / HoursPerDay
 	M := float64(t.Minute()) / MinutesPerDay
-	S := float64(t.Second()) / SecondsPerDay
+	S := (float64(t.Second()) + float64(t.Nanosecond())/1e9) / SecondsPerDay
 
 	_, offset := t.Zone()
 	Z := float64(offset) / SecondsPerDay
@@ -86,6 +87,25 @@ func ToJulianDay(t time.Time) float64 {
 	return float64(A+B-C+D) + H + M + S + Z
 }
 
+// Transforms a julian day into a time in loc, to the nearest microsecond (a
+// float64 julian day itself only resolves about 40µs for modern dates). The
+// conversion works on the continuous day count, so it holds across the
+// Gregorian calendar reform and for negative (BCE) years. Like every
+// time.Time, dates before the reform are expressed in the proleptic Gregorian
+// calendar, e.g. the julian calendar date 1582-10-04 is 1582-10-14.
+func FromJulianDay(jd float64, loc *time.Location) time.Time {
+	// Split off the whole days first so the fraction keeps full precision.
+	d := math.Floor(jd)
+	f := jd - d - 0.5
+
+	days := int64(d) - int64(math.Floor(JUnixEpoch))
+	us := math.Round(f * SecondsPerDay * 1e6)
+
+	return time.Unix(days*int64(SecondsPerDay), 0).
+		Add(time.Duration(us) * time.Microsecond).
+		In(loc)
+}
+
 // Transforms a julian day to century.
 func ToJulianCentury(jd float64) float64 {
 	return jd * 31557600.0 / 3155695200.0
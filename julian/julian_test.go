@@ -17,6 +17,7 @@ package julian
 import (
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -77,3 +78,52 @@ func TestToJulianCentury(t *testing.T) {
 		})
 	}
 }
+
+// FromJulianDay tests.
+func TestFromJulianDay(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		jd   float64
+		loc  *time.Location
+		t    time.Time
+	}{
+		{"J2000", J2000, time.UTC, time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"UnixEpoch", JUnixEpoch, time.UTC, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"SubSecond", J2000 + 0.25 + 0.5/SecondsPerDay, time.UTC, time.Date(2000, 1, 1, 18, 0, 0, 5e8, time.UTC)},
+		{"InLocation", J2000 + 0.25, ny, time.Date(2000, 1, 1, 13, 0, 0, 0, ny)},
+		{"GregorianReform", 2299160.5, time.UTC, time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"BeforeGregorianReform", 2299159.5, time.UTC, time.Date(1582, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{"CommonEra", 1721425.5, time.UTC, time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"BeforeCommonEra", 0.0, time.UTC, time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := FromJulianDay(tt.jd, tt.loc)
+			assert.WithinDuration(t, tt.t, ts, 50*time.Microsecond)
+			assert.Equal(t, tt.loc, ts.Location())
+		})
+	}
+}
+
+// Round trip tests between ToJulianDay and FromJulianDay.
+func TestJulianDayRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+	}{
+		{"Modern", time.Date(2024, 3, 10, 7, 16, 1, 250000000, time.UTC)},
+		{"Medieval", time.Date(1066, 10, 14, 9, 30, 0, 0, time.UTC)},
+		{"Ancient", time.Date(-584, 5, 28, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := FromJulianDay(ToJulianDay(tt.t), time.UTC)
+			assert.WithinDuration(t, tt.t, rt, 100*time.Microsecond)
+		})
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package julian
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Scale is a time scale in which julian days can be expressed.
+type Scale int
+
+const (
+	// Coordinated Universal Time, the civil time scale kept by time.Time.
+	UTC Scale = iota
+	// International Atomic Time.
+	TAI
+	// Terrestrial Time, the dynamical time used by the orbital formulas.
+	TT
+	// Universal Time, following the rotation of the Earth.
+	UT1
+	// Barycentric Dynamical Time.
+	TDB
+)
+
+const (
+	// TT - TAI in seconds.
+	TTMinusTAI = 32.184
+)
+
+// String returns the abbreviation of the time scale.
+func (s Scale) String() string {
+	switch s {
+	case UTC:
+		return "UTC"
+	case TAI:
+		return "TAI"
+	case TT:
+		return "TT"
+	case UT1:
+		return "UT1"
+	case TDB:
+		return "TDB"
+	default:
+		return "invalid"
+	}
+}
+
+// LeapSecond is an entry of a table of leap seconds: from Time on, TAI is ahead
+// of UTC by Offset seconds.
+type LeapSecond struct {
+	Time   time.Time
+	Offset float64
+}
+
+func leap(year int, month time.Month, offset float64) LeapSecond {
+	return LeapSecond{time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), offset}
+}
+
+// LeapSeconds is the built-in table of leap seconds, starting when UTC adopted
+// whole second offsets from TAI in 1972.
+var LeapSeconds = []LeapSecond{
+	leap(1972, time.January, 10),
+	leap(1972, time.July, 11),
+	leap(1973, time.January, 12),
+	leap(1974, time.January, 13),
+	leap(1975, time.January, 14),
+	leap(1976, time.January, 15),
+	leap(1977, time.January, 16),
+	leap(1978, time.January, 17),
+	leap(1979, time.January, 18),
+	leap(1980, time.January, 19),
+	leap(1981, time.July, 20),
+	leap(1982, time.July, 21),
+	leap(1983, time.July, 22),
+	leap(1985, time.July, 23),
+	leap(1988, time.January, 24),
+	leap(1990, time.January, 25),
+	leap(1991, time.January, 26),
+	leap(1992, time.July, 27),
+	leap(1993, time.July, 28),
+	leap(1994, time.July, 29),
+	leap(1996, time.January, 30),
+	leap(1997, time.July, 31),
+	leap(1999, time.January, 32),
+	leap(2006, time.January, 33),
+	leap(2009, time.January, 34),
+	leap(2012, time.July, 35),
+	leap(2015, time.July, 36),
+	leap(2017, time.January, 37),
+}
+
+// LeapSecondsExpire is the time after which LeapSeconds may be missing leap
+// seconds announced since, as given by the IERS leap-seconds.list it was last
+// checked against.
+var LeapSecondsExpire = time.Date(2026, time.December, 28, 0, 0, 0, 0, time.UTC)
+
+// Leap seconds are only used up to this date, the ΔT model is used beyond.
+var leapSecondsEnd = time.Date(2050, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Number of years after the end of the leap seconds over which ΔT moves from
+// the last leap second offset to the model, when the model has rejoined the
+// long-term parabola.
+const deltaTBlend = 100.0
+
+// TimeScales converts UTC times into other time scales, and carries the polar
+// motion measured along with UT1. The zero value uses the built-in table of leap
+// seconds and no Earth orientation measurements.
+type TimeScales struct {
+	// LeapSeconds is the table of leap seconds, sorted by time. LeapSeconds
+	// is used when it is nil.
+	LeapSeconds []LeapSecond
+
+	// UT1MinusUTC returns the measured UT1 - UTC in seconds at a UTC time, and
+	// false when there is no measurement. UT1 is taken to be UTC when it is nil
+	// or has no measurement, which is good to within a second since 1972.
+	UT1MinusUTC func(t time.Time) (float64, bool)
+
+	// PolarMotion returns the measured coordinates x and y of the pole in
+	// arcseconds at a UTC time, and false when there is no measurement. The
+	// pole is taken to be the reference pole when it is nil or has no
+	// measurement.
+	PolarMotion func(t time.Time) (float64, float64, bool)
+}
+
+// TAIMinusUTC returns the number of seconds TAI is ahead of UTC, and false
+// when t is outside the table of leap seconds.
+func (c TimeScales) TAIMinusUTC(t time.Time) (float64, bool) {
+	table := c.LeapSeconds
+	if table == nil {
+		table = LeapSeconds
+	}
+
+	if len(table) == 0 || t.Before(table[0].Time) || !t.Before(leapSecondsEnd) {
+		return 0, false
+	}
+
+	i := sort.Search(len(table), func(i int) bool {
+		return table[i].Time.After(t)
+	})
+
+	return table[i-1].Offset, true
+}
+
+// Returns UT1 - UTC in seconds.
+func (c TimeScales) ut1MinusUTC(t time.Time) float64 {
+	if c.UT1MinusUTC == nil {
+		return 0
+	}
+
+	dut1, ok := c.UT1MinusUTC(t)
+	if !ok {
+		return 0
+	}
+
+	return dut1
+}
+
+// Pole returns the coordinates x and y of the pole in arcseconds at a UTC time,
+// or zero when they are not measured.
+func (c TimeScales) Pole(t time.Time) (float64, float64) {
+	if c.PolarMotion == nil {
+		return 0, 0
+	}
+
+	x, y, ok := c.PolarMotion(t)
+	if !ok {
+		return 0, 0
+	}
+
+	return x, y
+}
+
+// Returns TT - UTC in seconds. Outside the table of leap seconds UTC is taken
+// to follow UT1, as it did before 1972. After the end of the table the model
+// is shifted to continue from the last leap second offset, and the shift fades
+// out over deltaTBlend years, so event times do not jump at the end.
+func (c TimeScales) ttMinusUTC(t time.Time) float64 {
+	if dat, ok := c.TAIMinusUTC(t); ok {
+		return dat + TTMinusTAI
+	}
+
+	y := decimalYear(t)
+	tt := EspenakMeeus(y) + c.ut1MinusUTC(t)
+	if t.Before(leapSecondsEnd) {
+		return tt
+	}
+
+	table := c.LeapSeconds
+	if table == nil {
+		table = LeapSeconds
+	}
+	if len(table) == 0 {
+		return tt
+	}
+
+	y_end := decimalYear(leapSecondsEnd)
+	w := 1 - (y-y_end)/deltaTBlend
+	if w <= 0 {
+		return tt
+	}
+
+	shift := table[len(table)-1].Offset + TTMinusTAI -
+		EspenakMeeus(y_end) - c.ut1MinusUTC(leapSecondsEnd)
+
+	return tt + w*shift
+}
+
+// DeltaT (ΔT) returns TT - UT1 in seconds at a UTC time.
+func (c TimeScales) DeltaT(t time.Time) float64 {
+	return c.ttMinusUTC(t) - c.ut1MinusUTC(t)
+}
+
+// Offset returns the number of seconds the time scale is ahead of UTC at a UTC
+// time.
+func (c TimeScales) Offset(t time.Time, s Scale) float64 {
+	switch s {
+	case TAI:
+		return c.ttMinusUTC(t) - TTMinusTAI
+	case TT:
+		return c.ttMinusUTC(t)
+	case UT1:
+		return c.ut1MinusUTC(t)
+	case TDB:
+		tt := c.ttMinusUTC(t)
+		return tt + TDBMinusTT(ToJulianDay(t.UTC())+tt/SecondsPerDay)
+	default:
+		return 0
+	}
+}
+
+// JulianDay transforms a time into a julian day in the time scale.
+func (c TimeScales) JulianDay(t time.Time, s Scale) float64 {
+	return ToJulianDay(t.UTC()) + c.Offset(t, s)/SecondsPerDay
+}
+
+// FromJulianDay transforms a julian day in the time scale into a time in loc.
+func (c TimeScales) FromJulianDay(jd float64, s Scale, loc *time.Location) time.Time {
+	// The offset depends on the UTC time being searched for, but changes
+	// slowly enough that a couple of steps settle it.
+	t := FromJulianDay(jd, time.UTC)
+	for range 2 {
+		off := c.Offset(t, s)
+		t = FromJulianDay(jd-off/SecondsPerDay, time.UTC)
+	}
+
+	return t.In(loc)
+}
+
+// Transforms a time into a julian day in the time scale, using the built-in
+// table of leap seconds.
+func JulianDayIn(t time.Time, s Scale) float64 {
+	return TimeScales{}.JulianDay(t, s)
+}
+
+// Transforms a julian day in the time scale into a time in loc, using the
+// built-in table of leap seconds.
+func FromJulianDayIn(jd float64, s Scale, loc *time.Location) time.Time {
+	return TimeScales{}.FromJulianDay(jd, s, loc)
+}
+
+// DeltaT (ΔT) returns TT - UT1 in seconds at a UTC time, using the built-in
+// table of leap seconds.
+func DeltaT(t time.Time) float64 {
+	return TimeScales{}.DeltaT(t)
+}
+
+// TDBMinusTT returns TDB - TT in seconds at a julian day (TT), from the two
+// largest periodic terms.
+func TDBMinusTT(jd float64) float64 {
+	g := (357.53 + 0.9856003*(jd-J2000)) * math.Pi / 180
+
+	return 0.001657*math.Sin(g) + 0.000014*math.Sin(2*g)
+}
+
+// Returns the decimal year of a time, as used by the ΔT polynomials.
+func decimalYear(t time.Time) float64 {
+	t = t.UTC()
+
+	return float64(t.Year()) + (float64(t.Month())-0.5)/12
+}
+
+// EspenakMeeus returns ΔT (TT - UT1) in seconds for a decimal year, from the
+// polynomial expressions of Espenak and Meeus fitted to historical
+// observations.
+func EspenakMeeus(y float64) float64 {
+	switch {
+	case y < -500:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 500:
+		u := y / 100
+		return poly(u, 10583.6, -1014.41, 33.78311, -5.952053,
+			-0.1798452, 0.022174192, 0.0090316521)
+	case y < 1600:
+		u := (y - 1000) / 100
+		return poly(u, 1574.2, -556.01, 71.23472, 0.319781,
+			-0.8503463, -0.005050998, 0.0083572073)
+	case y < 1700:
+		t := y - 1600
+		return poly(t, 120, -0.9808, -0.01532, 1.0/7129)
+	case y < 1800:
+		t := y - 1700
+		return poly(t, 8.83, 0.1603, -0.0059285, 0.00013336, -1.0/1174000)
+	case y < 1860:
+		t := y - 1800
+		return poly(t, 13.72, -0.332447, 0.0068612, 0.0041116, -0.00037436,
+			0.0000121272, -0.0000001699, 0.000000000875)
+	case y < 1900:
+		t := y - 1860
+		return poly(t, 7.62, 0.5737, -0.251754, 0.01680668, -0.0004473624,
+			1.0/233174)
+	case y < 1920:
+		t := y - 1900
+		return poly(t, -2.79, 1.494119, -0.0598939, 0.0061966, -0.000197)
+	case y < 1941:
+		t := y - 1920
+		return poly(t, 21.20, 0.84493, -0.076100, 0.0020936)
+	case y < 1961:
+		t := y - 1950
+		return poly(t, 29.07, 0.407, -1.0/233, 1.0/2547)
+	case y < 1986:
+		t := y - 1975
+		return poly(t, 45.45, 1.067, -1.0/260, -1.0/718)
+	case y < 2005:
+		t := y - 2000
+		return poly(t, 63.86, 0.3345, -0.060374, 0.0017275, 0.000651814,
+			0.00002373599)
+	case y < 2050:
+		t := y - 2000
+		return poly(t, 62.92, 0.32217, 0.005589)
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	default:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	}
+}
+
+// Evaluates the polynomial with coefficients c (lowest order first) at x.
+func poly(x float64, c ...float64) float64 {
+	var p float64
+	for i := len(c) - 1; i >= 0; i-- {
+		p = p*x + c[i]
+	}
+
+	return p
+}
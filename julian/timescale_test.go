@@ -0,0 +1,193 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package julian
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TAIMinusUTC tests.
+func TestTAIMinusUTC(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		dat  float64
+		ok   bool
+	}{
+		{"BeforeLeapSeconds", time.Date(1971, 12, 31, 0, 0, 0, 0, time.UTC), 0, false},
+		{"FirstEntry", time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10, true},
+		{"BeforeLeapSecond", time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 36, true},
+		{"AfterLeapSecond", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37, true},
+		{"Present", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), 37, true},
+		{"FarFuture", time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dat, ok := TimeScales{}.TAIMinusUTC(tt.t)
+			assert.Equal(t, tt.dat, dat)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+// EspenakMeeus tests against the values tabulated by NASA.
+func TestEspenakMeeus(t *testing.T) {
+	tests := []struct {
+		name string
+		y    float64
+		dt   float64
+	}{
+		{"Year-500", -500, 17190},
+		{"Year0", 0, 10580},
+		{"Year1000", 1000, 1570},
+		{"Year1600", 1600, 120},
+		{"Year1700", 1700, 9},
+		{"Year1800", 1800, 14},
+		{"Year1900", 1900, -3},
+		{"Year1950", 1950, 29},
+		{"Year2000", 2000, 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.dt, EspenakMeeus(tt.y), 20)
+		})
+	}
+}
+
+// DeltaT tests.
+func TestDeltaT(t *testing.T) {
+	tests := []struct {
+		name string
+		c    TimeScales
+		t    time.Time
+		dt   float64
+	}{
+		{"Present", TimeScales{}, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), 69.184},
+		{"Historical", TimeScales{}, time.Date(1000, 6, 1, 0, 0, 0, 0, time.UTC), EspenakMeeus(1000 + 5.5/12)},
+		{
+			"MeasuredUT1",
+			TimeScales{UT1MinusUTC: func(time.Time) (float64, bool) { return -0.1, true }},
+			time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			69.284,
+		},
+		{"FarFuture", TimeScales{}, time.Date(2200, 6, 1, 0, 0, 0, 0, time.UTC), EspenakMeeus(2200 + 5.5/12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.dt, tt.c.DeltaT(tt.t), 1e-9)
+		})
+	}
+}
+
+// DeltaT tests: ΔT carries on from the leap seconds without a jump, and
+// joins the model a century later.
+func TestDeltaTEndOfLeapSeconds(t *testing.T) {
+	before := DeltaT(leapSecondsEnd.Add(-time.Second))
+	after := DeltaT(leapSecondsEnd)
+	assert.Equal(t, 69.184, before)
+	assert.InDelta(t, before, after, 1e-9)
+
+	prev := after
+	for y := 2051; y <= 2160; y++ {
+		dt := DeltaT(time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.InDelta(t, prev, dt, 3, "%d", y)
+		prev = dt
+	}
+	assert.Equal(t, EspenakMeeus(2160+0.5/12), prev)
+}
+
+// Offset tests.
+func TestOffset(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		s    Scale
+		off  float64
+	}{
+		{"ForUTC", UTC, 0},
+		{"ForTAI", TAI, 37},
+		{"ForTT", TT, 69.184},
+		{"ForUT1", UT1, 0},
+		{"ForTDB", TDB, 69.184 + TDBMinusTT(ToJulianDay(ts)+69.184/SecondsPerDay)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.off, TimeScales{}.Offset(ts, tt.s), 1e-9)
+		})
+	}
+
+	// TDB stays within 2ms of TT.
+	assert.InDelta(t, 0, TDBMinusTT(ToJulianDay(ts)), 0.002)
+}
+
+// Pole tests.
+func TestPole(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		c    TimeScales
+		x, y float64
+	}{
+		{"Unset", TimeScales{}, 0, 0},
+		{"Measured", TimeScales{PolarMotion: func(time.Time) (float64, float64, bool) {
+			return 0.1, 0.3, true
+		}}, 0.1, 0.3},
+		{"Unmeasured", TimeScales{PolarMotion: func(time.Time) (float64, float64, bool) {
+			return 0.1, 0.3, false
+		}}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := tt.c.Pole(ts)
+			assert.Equal(t, tt.x, x)
+			assert.Equal(t, tt.y, y)
+		})
+	}
+}
+
+// JulianDayIn and FromJulianDayIn tests.
+func TestJulianDayIn(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		s    Scale
+		jd   float64
+	}{
+		{"ForUTC", UTC, 2460310.5},
+		{"ForTT", TT, 2460310.5 + 69.184/SecondsPerDay},
+		{"ForTAI", TAI, 2460310.5 + 37/SecondsPerDay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jd := JulianDayIn(ts, tt.s)
+			assert.InDelta(t, tt.jd, jd, 1e-9)
+
+			rt := FromJulianDayIn(jd, tt.s, time.UTC)
+			assert.WithinDuration(t, ts, rt, 100*time.Microsecond)
+		})
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package noaa reproduces the NOAA Solar Calculator: the formulas of its
+// spreadsheets for the position of the sun, and the procedure of its web page
+// for sunrise, solar noon and sunset. They are based on Meeus and are accurate
+// to about a minute of arc and a minute of time between 1800 and 2100.
+//
+// The operations follow the NOAA sources in the same order so the results
+// agree with theirs to the last bit, which is why the package does not share
+// the formulas of the rest of the module. No ΔT is applied: the formulas run
+// on UT.
+package noaa
+
+import (
+	"math"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/solarposition"
+)
+
+// Zenith angle of the center of the solar disk at sunrise and sunset.
+const zenithSunrise = 90.833
+
+// Calculator is the NOAA Solar Calculator as a solarposition.Engine.
+type Calculator struct{}
+
+var _ solarposition.Engine = Calculator{}
+
+// Result holds the columns of the NOAA spreadsheet, in degrees unless noted
+// otherwise.
+type Result struct {
+	JulianDay               float64
+	JulianCentury           float64
+	GeomMeanLongSun         float64
+	GeomMeanAnomSun         float64
+	EccentEarthOrbit        float64
+	SunEqOfCtr              float64
+	SunTrueLong             float64
+	SunTrueAnom             float64
+	SunRadVector            float64 // in AU
+	SunAppLong              float64
+	MeanObliqEcliptic       float64
+	ObliqCorr               float64
+	SunRtAscen              float64
+	SunDeclin               float64
+	VarY                    float64
+	EqOfTime                float64 // in minutes
+	HASunrise               float64
+	TrueSolarTime           float64 // in minutes
+	HourAngle               float64
+	SolarZenith             float64
+	SolarElevation          float64
+	AtmosphericRefraction   float64
+	SolarElevationCorrected float64
+	SolarAzimuth            float64 // clockwise from north
+}
+
+// Conversions as written in the NOAA sources.
+func degToRad(angle float64) float64 {
+	return math.Pi * angle / 180.0
+}
+
+func radToDeg(angle float64) float64 {
+	return 180.0 * angle / math.Pi
+}
+
+// Remainder with the sign of the divisor, like MOD in a spreadsheet.
+func mod(x, y float64) float64 {
+	r := math.Mod(x, y)
+	if r < 0 {
+		r += y
+	}
+
+	return r
+}
+
+// Calculate evaluates the columns of the NOAA spreadsheet. The sunrise, solar
+// noon and sunset columns depend on the time zone and are left to the event
+// functions.
+//
+// jd: julian day (UT).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func Calculate(jd float64, lat, lon float64) Result {
+	r := Result{JulianDay: jd}
+	G := (jd - 2451545) / 36525
+	r.JulianCentury = G
+
+	r.GeomMeanLongSun = mod(280.46646+G*(36000.76983+G*0.0003032), 360)
+	r.GeomMeanAnomSun = 357.52911 + G*(35999.05029-0.0001537*G)
+	r.EccentEarthOrbit = 0.016708634 - G*(0.000042037+0.0000001267*G)
+
+	J := r.GeomMeanAnomSun
+	r.SunEqOfCtr = math.Sin(degToRad(J))*(1.914602-G*(0.004817+0.000014*G)) +
+		math.Sin(degToRad(2*J))*(0.019993-0.000101*G) +
+		math.Sin(degToRad(3*J))*0.000289
+	r.SunTrueLong = r.GeomMeanLongSun + r.SunEqOfCtr
+	r.SunTrueAnom = r.GeomMeanAnomSun + r.SunEqOfCtr
+
+	K := r.EccentEarthOrbit
+	r.SunRadVector = (1.000001018 * (1 - K*K)) /
+		(1 + K*math.Cos(degToRad(r.SunTrueAnom)))
+	r.SunAppLong = r.SunTrueLong - 0.00569 -
+		0.00478*math.Sin(degToRad(125.04-1934.136*G))
+	r.MeanObliqEcliptic = 23 + (26+(21.448-G*(46.815+G*(0.00059-G*0.001813)))/60)/60
+	r.ObliqCorr = r.MeanObliqEcliptic + 0.00256*math.Cos(degToRad(125.04-1934.136*G))
+
+	P, R := r.SunAppLong, r.ObliqCorr
+	r.SunRtAscen = radToDeg(math.Atan2(
+		math.Cos(degToRad(R))*math.Sin(degToRad(P)), math.Cos(degToRad(P)),
+	))
+	r.SunDeclin = radToDeg(math.Asin(math.Sin(degToRad(R)) * math.Sin(degToRad(P))))
+
+	U := math.Tan(degToRad(R/2)) * math.Tan(degToRad(R/2))
+	r.VarY = U
+	I := r.GeomMeanLongSun
+	r.EqOfTime = 4 * radToDeg(U*math.Sin(2*degToRad(I))-
+		2*K*math.Sin(degToRad(J))+
+		4*K*U*math.Sin(degToRad(J))*math.Cos(2*degToRad(I))-
+		0.5*U*U*math.Sin(4*degToRad(I))-
+		1.25*K*K*math.Sin(2*degToRad(J)))
+
+	T := r.SunDeclin
+	r.HASunrise = radToDeg(math.Acos(
+		math.Cos(degToRad(zenithSunrise))/(math.Cos(degToRad(lat))*math.Cos(degToRad(T))) -
+			math.Tan(degToRad(lat))*math.Tan(degToRad(T)),
+	))
+
+	// Minutes since midnight UT; the spreadsheet takes the longitude east.
+	minutes := (jd - 0.5 - math.Floor(jd-0.5)) * 1440
+	r.TrueSolarTime = mod(minutes+r.EqOfTime+4*-lon, 1440)
+	if r.TrueSolarTime/4 < 0 {
+		r.HourAngle = r.TrueSolarTime/4 + 180
+	} else {
+		r.HourAngle = r.TrueSolarTime/4 - 180
+	}
+
+	r.SolarZenith = radToDeg(math.Acos(
+		math.Sin(degToRad(lat))*math.Sin(degToRad(T)) +
+			math.Cos(degToRad(lat))*math.Cos(degToRad(T))*math.Cos(degToRad(r.HourAngle)),
+	))
+	r.SolarElevation = 90 - r.SolarZenith
+	r.AtmosphericRefraction = refraction(r.SolarElevation)
+	r.SolarElevationCorrected = r.SolarElevation + r.AtmosphericRefraction
+
+	az := radToDeg(math.Acos(
+		((math.Sin(degToRad(lat)) * math.Cos(degToRad(r.SolarZenith))) - math.Sin(degToRad(T))) /
+			(math.Cos(degToRad(lat)) * math.Sin(degToRad(r.SolarZenith))),
+	))
+	if r.HourAngle > 0 {
+		r.SolarAzimuth = mod(az+180, 360)
+	} else {
+		r.SolarAzimuth = mod(540-az, 360)
+	}
+
+	return r
+}
+
+// Approximate atmospheric refraction of the NOAA spreadsheet (in degrees).
+//
+// h: elevation of the sun without refraction.
+func refraction(h float64) float64 {
+	var arcsec float64
+	switch {
+	case h > 85:
+		arcsec = 0
+	case h > 5:
+		arcsec = 58.1/math.Tan(degToRad(h)) -
+			0.07/math.Pow(math.Tan(degToRad(h)), 3) +
+			0.000086/math.Pow(math.Tan(degToRad(h)), 5)
+	case h > -0.575:
+		arcsec = 1735 + h*(-518.2+h*(103.4+h*(-12.79+h*0.711)))
+	default:
+		arcsec = -20.772 / math.Tan(degToRad(h))
+	}
+
+	return arcsec / 3600
+}
+
+// Horizontal calculates the azimuth, measured from the south and between -180°
+// and 180° like solarposition.Azimuth, and the altitude of the sun corrected
+// for refraction.
+//
+// jd: julian day (UT).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (Calculator) Horizontal(jd float64, lat, lon float64) (float64, float64, error) {
+	r := Calculate(jd, lat, lon)
+
+	return r.SolarAzimuth - 180, r.SolarElevationCorrected, nil
+}
+
+// Returns the time of sunrise or sunset in minutes from 0h UT, for the sun at
+// julian day jd. The longitude is taken east, as the web page does.
+func calcSunriseSetUTC(rise bool, jd, lat, lon float64) (float64, error) {
+	r := Calculate(jd, lat, 0)
+
+	arg := math.Cos(degToRad(zenithSunrise))/
+		(math.Cos(degToRad(lat))*math.Cos(degToRad(r.SunDeclin))) -
+		math.Tan(degToRad(lat))*math.Tan(degToRad(r.SunDeclin))
+	if arg > 1 {
+		return 0, solarposition.ErrPolarNight
+	} else if arg < -1 {
+		return 0, solarposition.ErrMidnightSun
+	}
+
+	hourAngle := math.Acos(arg)
+	if !rise {
+		hourAngle = -hourAngle
+	}
+
+	delta := lon + radToDeg(hourAngle)
+
+	return 720 - (4.0 * delta) - r.EqOfTime, nil
+}
+
+// Returns the time of sunrise or sunset as the web page does: once for the sun
+// at 0h UT, then again for the sun at that first estimate.
+func calcSunriseSet(rise bool, jd, lat, lon float64) (float64, error) {
+	jd0 := math.Floor(jd-0.5) + 0.5
+
+	timeUTC, err := calcSunriseSetUTC(rise, jd0, lat, -lon)
+	if err != nil {
+		return 0, err
+	}
+
+	newTimeUTC, err := calcSunriseSetUTC(rise, jd0+timeUTC/1440.0, lat, -lon)
+	if err != nil {
+		return 0, err
+	}
+
+	return jd0 + newTimeUTC/1440.0, nil
+}
+
+// Solar noon time is the moment of the transit of the sun on the date of the
+// julian day, as shown by the NOAA web page.
+//
+// jd: julian day (UT).
+//
+// lon: longitude (west).
+func SolarNoonTime(jd float64, lon float64) float64 {
+	jd0 := math.Floor(jd-0.5) + 0.5
+	longitude := -lon
+
+	eqTime := Calculate(jd0-longitude/360.0, 0, 0).EqOfTime
+	solNoonOffset := 720.0 - (longitude * 4) - eqTime
+	eqTime = Calculate(jd0+solNoonOffset/1440.0, 0, 0).EqOfTime
+
+	return jd0 + (720-(longitude*4)-eqTime)/1440.0
+}
+
+// Sunrise time is the moment of sunrise on the date of the julian day, as shown
+// by the NOAA web page. ErrPolarNight or ErrMidnightSun is returned when the
+// sun does not rise on that day.
+//
+// jd: julian day (UT).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func SunriseTime(jd float64, lat, lon float64) (float64, error) {
+	return calcSunriseSet(true, jd, lat, lon)
+}
+
+// Sunset time is the moment of sunset on the date of the julian day, as shown
+// by the NOAA web page. ErrPolarNight or ErrMidnightSun is returned when the
+// sun does not set on that day.
+//
+// jd: julian day (UT).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func SunsetTime(jd float64, lat, lon float64) (float64, error) {
+	return calcSunriseSet(false, jd, lat, lon)
+}
+
+// Returns the julian day at 0h UT of the calendar date, which the web page
+// calculates the events of a local date from.
+func dateJulianDay(date time.Time) float64 {
+	y, m, d := date.Date()
+
+	return julian.ToJulianDay(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+}
+
+// Sunrise returns the time of sunrise on the calendar date in loc, as shown by
+// the NOAA web page for that date and time zone. Like the web page, it returns
+// the event of the UT date, which can fall on the neighbouring local date far
+// from Greenwich.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func Sunrise(date time.Time, loc *time.Location, lat, lon float64) (time.Time, error) {
+	J, err := SunriseTime(dateJulianDay(date), lat, lon)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return julian.FromJulianDay(J, loc), nil
+}
+
+// SolarNoon returns the time of solar noon on the calendar date in loc, as
+// shown by the NOAA web page for that date and time zone. See Sunrise for
+// dates far from Greenwich.
+//
+// lon: longitude (west).
+func SolarNoon(date time.Time, loc *time.Location, lon float64) time.Time {
+	return julian.FromJulianDay(SolarNoonTime(dateJulianDay(date), lon), loc)
+}
+
+// Sunset returns the time of sunset on the calendar date in loc, as shown by the
+// NOAA web page for that date and time zone. See Sunrise for dates far from
+// Greenwich.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func Sunset(date time.Time, loc *time.Location, lat, lon float64) (time.Time, error) {
+	J, err := SunsetTime(dateJulianDay(date), lat, lon)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return julian.FromJulianDay(J, loc), nil
+}
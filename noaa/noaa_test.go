@@ -0,0 +1,160 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noaa
+
+import (
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/codymj/celestia/solarposition"
+	"github.com/codymj/celestia/spa"
+	"github.com/stretchr/testify/assert"
+)
+
+// Calculate tests, against the first row of the NOAA spreadsheet: 2010-06-21
+// 0:06 at -6h, latitude 40, longitude -105 (east).
+func TestCalculate(t *testing.T) {
+	r := Calculate(2455368.75+0.1/24, 40, 105)
+
+	tests := []struct {
+		name     string
+		expected float64
+		actual   float64
+	}{
+		{"JulianCentury", 0.10468868, r.JulianCentury},
+		{"GeomMeanLongSun", 89.3396636, r.GeomMeanLongSun},
+		{"GeomMeanAnomSun", 4126.2222923, r.GeomMeanAnomSun},
+		{"EccentEarthOrbit", 0.0167042, r.EccentEarthOrbit},
+		{"SunEqOfCtr", 0.4467999, r.SunEqOfCtr},
+		{"SunRadVector", 1.0162401, r.SunRadVector},
+		{"SunAppLong", 89.7854392, r.SunAppLong},
+		{"ObliqCorr", 23.4384863, r.ObliqCorr},
+		{"SunRtAscen", 89.7661433, r.SunRtAscen},
+		{"SunDeclin", 23.4383122, r.SunDeclin},
+		{"EqOfTime", -1.7063078, r.EqOfTime},
+		{"HASunrise", 112.6103464, r.HASunrise},
+		{"SolarElevationCorrected", -25.2334820, r.SolarElevationCorrected},
+		{"SolarAzimuth", 345.8691024, r.SolarAzimuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.actual, 1e-7)
+		})
+	}
+}
+
+// Refraction tests, covering each branch of the piecewise model.
+func TestRefraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		h        float64
+		expected float64
+	}{
+		{"Zenith", 89, 0},
+		{"High", 45, (58.1 - 0.07 + 0.000086) / 3600},
+		{"Horizon", 0, 1735.0 / 3600},
+		{"Below", -10, -20.772 / math.Tan(-10*math.Pi/180) / 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, refraction(tt.h), 1e-12)
+		})
+	}
+}
+
+// Horizontal tests: the calculator follows SPA with a standard atmosphere to
+// within its accuracy while the sun is up, over a year at 52°N.
+func TestHorizontal(t *testing.T) {
+	var engine solarposition.Engine = Calculator{}
+	s := spa.New()
+
+	for jd := 2460311.0; jd < 2460311.0+366; jd += 0.37 {
+		A, h, err := engine.Horizontal(jd, 52, -5.0)
+		assert.NoError(t, err)
+
+		r := s.Calculate(jd, 52, -5.0)
+		if r.E > 5 {
+			assert.InDelta(t, r.E, h, 0.02)
+			assert.InDelta(t, r.AzimuthAstro, A, 0.02)
+		}
+	}
+}
+
+// Local event tests, against the events of the solarposition package on the
+// same date. Like the NOAA web page, the events far from Greenwich are those of
+// the UT date and fall on the next local date.
+func TestLocalEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		loc     string
+		lat     float64
+		lon     float64
+		sunrise string
+		noon    string
+		sunset  string
+	}{
+		{
+			"FallBack", "2024-11-03", "America/New_York", 40.7128, 74.0060,
+			"2024-11-03T06:29:18-05:00",
+			"2024-11-03T11:39:32-05:00",
+			"2024-11-03T16:49:16-05:00",
+		},
+		{
+			"DateLine", "2024-06-01", "Pacific/Kiritimati", 1.87, 157.4,
+			"2024-06-02T06:20:54+14:00",
+			"2024-06-02T12:27:36+14:00",
+			"2024-06-02T18:34:18+14:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.loc)
+			assert.NoError(t, err)
+			date, err := time.ParseInLocation(time.DateOnly, tt.date, loc)
+			assert.NoError(t, err)
+
+			sunrise, err := Sunrise(date, loc, tt.lat, tt.lon)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.sunrise, sunrise.Truncate(time.Second).Format(time.RFC3339))
+			expected, _ := solarposition.Sunrise(sunrise, loc, tt.lat, tt.lon)
+			assert.WithinDuration(t, expected, sunrise, time.Minute)
+
+			noon := SolarNoon(date, loc, tt.lon)
+			assert.Equal(t, tt.noon, noon.Truncate(time.Second).Format(time.RFC3339))
+			expected, _ = solarposition.SolarNoon(noon, loc, tt.lon)
+			assert.WithinDuration(t, expected, noon, time.Minute)
+
+			sunset, err := Sunset(date, loc, tt.lat, tt.lon)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.sunset, sunset.Truncate(time.Second).Format(time.RFC3339))
+			expected, _ = solarposition.Sunset(sunset, loc, tt.lat, tt.lon)
+			assert.WithinDuration(t, expected, sunset, time.Minute)
+		})
+	}
+}
+
+// Polar day and night tests.
+func TestPolar(t *testing.T) {
+	_, err := SunriseTime(2460482.5, 78.2, -15.6)
+	assert.ErrorIs(t, err, solarposition.ErrMidnightSun)
+	_, err = SunsetTime(2460665.5, 78.2, -15.6)
+	assert.ErrorIs(t, err, solarposition.ErrPolarNight)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nutation calculates the nutation of the Earth's axis and the
+// obliquity of the ecliptic, from the IAU 1980 theory of nutation.
+package nutation
+
+import (
+	"math"
+
+	"github.com/codymj/celestia/julian"
+)
+
+const (
+	RAD = math.Pi / 180
+	DEG = 180 / math.Pi
+
+	// Arcseconds per degree.
+	ArcsecPerDegree = 3600.0
+)
+
+// term is a periodic term of the nutation series. The argument is a multiple
+// of the fundamental arguments D, M, M', F and Ω, the coefficients are in units
+// of 0.0001".
+type term struct {
+	D, M, Mp, F, O int
+	a, b, c, d     float64
+}
+
+// IAU 1980 nutation series, as tabulated by Meeus, Astronomical Algorithms,
+// table 22.A.
+var terms = [...]term{
+	{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+	{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+	{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+	{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+	{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+	{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+	{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+	{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+	{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+	{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+	{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+	{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+	{0, 0, -1, 2, 2, 123, 0, -53, 0},
+	{2, 0, 0, 0, 0, 63, 0, 0, 0},
+	{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+	{2, 0, -1, 2, 2, -59, 0, 26, 0},
+	{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+	{0, 0, 1, 2, 1, -51, 0, 27, 0},
+	{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+	{0, 0, -2, 2, 1, 46, 0, -24, 0},
+	{2, 0, 0, 2, 2, -38, 0, 16, 0},
+	{0, 0, 2, 2, 2, -31, 0, 13, 0},
+	{0, 0, 2, 0, 0, 29, 0, 0, 0},
+	{-2, 0, 1, 2, 2, 29, 0, -12, 0},
+	{0, 0, 0, 2, 0, 26, 0, 0, 0},
+	{-2, 0, 0, 2, 0, -22, 0, 0, 0},
+	{0, 0, -1, 2, 1, 21, 0, -10, 0},
+	{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+	{2, 0, -1, 0, 1, 16, 0, -8, 0},
+	{-2, 2, 0, 2, 2, -16, 0.1, 7, 0},
+	{0, 1, 0, 0, 1, -15, 0, 9, 0},
+	{-2, 0, 1, 0, 1, -13, 0, 7, 0},
+	{0, -1, 0, 0, 1, -12, 0, 6, 0},
+	{0, 0, 2, -2, 0, 11, 0, 0, 0},
+	{2, 0, -1, 2, 1, -10, 0, 5, 0},
+	{2, 0, 1, 2, 2, -8, 0, 3, 0},
+	{0, 1, 0, 2, 2, 7, 0, -3, 0},
+	{-2, 1, 1, 0, 0, -7, 0, 0, 0},
+	{0, -1, 0, 2, 2, -7, 0, 3, 0},
+	{2, 0, 0, 2, 1, -7, 0, 3, 0},
+	{2, 0, 1, 0, 0, 6, 0, 0, 0},
+	{-2, 0, 2, 2, 2, 6, 0, -3, 0},
+	{-2, 0, 1, 2, 1, 6, 0, -3, 0},
+	{2, 0, -2, 0, 1, -6, 0, 3, 0},
+	{2, 0, 0, 0, 1, -6, 0, 3, 0},
+	{0, -1, 1, 0, 0, 5, 0, 0, 0},
+	{-2, -1, 0, 2, 1, -5, 0, 3, 0},
+	{-2, 0, 0, 0, 1, -5, 0, 3, 0},
+	{0, 0, 2, 2, 1, -5, 0, 3, 0},
+	{-2, 0, 2, 0, 1, 4, 0, 0, 0},
+	{-2, 1, 0, 2, 1, 4, 0, 0, 0},
+	{0, 0, 1, -2, 0, 4, 0, 0, 0},
+	{-1, 0, 1, 0, 0, -4, 0, 0, 0},
+	{-2, 1, 0, 0, 0, -4, 0, 0, 0},
+	{1, 0, 0, 0, 0, -4, 0, 0, 0},
+	{0, 0, 1, 2, 0, 3, 0, 0, 0},
+	{0, 0, -2, 2, 2, -3, 0, 0, 0},
+	{-1, -1, 1, 0, 0, -3, 0, 0, 0},
+	{0, 1, 1, 0, 0, -3, 0, 0, 0},
+	{0, -1, 1, 2, 2, -3, 0, 0, 0},
+	{2, -1, -1, 2, 2, -3, 0, 0, 0},
+	{0, 0, 3, 2, 2, -3, 0, 0, 0},
+	{2, -1, 0, 2, 2, -3, 0, 0, 0},
+}
+
+// Returns julian centuries of TT since J2000.
+func centuries(jde float64) float64 {
+	return (jde - julian.J2000) / 36525
+}
+
+// Returns the cubic polynomial c0 + c1 T + c2 T² + c3 T³.
+func cubic(T, c0, c1, c2, c3 float64) float64 {
+	return c0 + T*(c1+T*(c2+T*c3))
+}
+
+// Nutation returns the nutation in longitude (Δψ) and in obliquity (Δε) in
+// degrees.
+//
+// jde: julian day (TT).
+func Nutation(jde float64) (float64, float64) {
+	T := centuries(jde)
+
+	// Mean elongation of the moon from the sun.
+	D := cubic(T, 297.85036, 445267.111480, -0.0019142, 1.0/189474)
+	// Mean anomaly of the sun.
+	M := cubic(T, 357.52772, 35999.050340, -0.0001603, -1.0/300000)
+	// Mean anomaly of the moon.
+	Mp := cubic(T, 134.96298, 477198.867398, 0.0086972, 1.0/56250)
+	// Argument of latitude of the moon.
+	F := cubic(T, 93.27191, 483202.017538, -0.0036825, 1.0/327270)
+	// Longitude of the ascending node of the moon's orbit.
+	O := cubic(T, 125.04452, -1934.136261, 0.0020708, 1.0/450000)
+
+	var dpsi, deps float64
+	for _, t := range terms {
+		arg := (float64(t.D)*D + float64(t.M)*M + float64(t.Mp)*Mp +
+			float64(t.F)*F + float64(t.O)*O) * RAD
+		dpsi += (t.a + t.b*T) * math.Sin(arg)
+		deps += (t.c + t.d*T) * math.Cos(arg)
+	}
+
+	return dpsi * 0.0001 / ArcsecPerDegree, deps * 0.0001 / ArcsecPerDegree
+}
+
+// Mean obliquity (ε0) of the ecliptic in degrees, from the polynomial of
+// Laskar, valid within 10000 years of J2000.
+//
+// jde: julian day (TT).
+func MeanObliquity(jde float64) float64 {
+	U := centuries(jde) / 100
+
+	e0 := 84381.448 + U*(-4680.93+U*(-1.55+U*(1999.25+U*(-51.38+U*(-249.67+
+		U*(-39.05+U*(7.12+U*(27.87+U*(5.79+U*2.45)))))))))
+
+	return e0 / ArcsecPerDegree
+}
+
+// True obliquity (ε) of the ecliptic in degrees, the mean obliquity corrected
+// for nutation.
+//
+// jde: julian day (TT).
+func TrueObliquity(jde float64) float64 {
+	_, deps := Nutation(jde)
+
+	return MeanObliquity(jde) + deps
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nutation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Nutation tests, against Meeus example 22.a and the NREL SPA example.
+func TestNutation(t *testing.T) {
+	tests := []struct {
+		name string
+		jde  float64
+		dpsi float64
+		deps float64
+		tol  float64
+	}{
+		{"Meeus22a", 2446895.5, -3.788 / ArcsecPerDegree, 9.443 / ArcsecPerDegree, 0.0005 / ArcsecPerDegree},
+		{"SPA", 2452930.312847 + 67.0/86400, -0.00399840, 0.00166657, 1e-7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpsi, deps := Nutation(tt.jde)
+			assert.InDelta(t, tt.dpsi, dpsi, tt.tol)
+			assert.InDelta(t, tt.deps, deps, tt.tol)
+		})
+	}
+}
+
+// Obliquity tests, against Meeus example 22.a.
+func TestObliquity(t *testing.T) {
+	e0 := 23 + 26.0/60 + 27.407/ArcsecPerDegree
+	e := 23 + 26.0/60 + 36.850/ArcsecPerDegree
+
+	assert.InDelta(t, e0, MeanObliquity(2446895.5), 0.001/ArcsecPerDegree)
+	assert.InDelta(t, e, TrueObliquity(2446895.5), 0.001/ArcsecPerDegree)
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sidereal calculates the sidereal time of the Earth following the IAU
+// standards: the Earth rotation angle, Greenwich mean sidereal time and
+// Greenwich apparent sidereal time.
+package sidereal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/nutation"
+)
+
+const (
+	RAD = math.Pi / 180
+	DEG = 180 / math.Pi
+)
+
+// Normalizes angles to be between 0 degrees and 360 degrees.
+func normalize360(angle float64) float64 {
+	angle = math.Mod(angle, 360.0)
+	if angle < 0 {
+		angle += 360.0
+	}
+
+	return angle
+}
+
+// Earth rotation angle (ERA) is the angle between the celestial and the
+// terrestrial intermediate origins, in degrees (IAU 2000).
+//
+// jd: julian day (UT1).
+func EarthRotationAngle(jd float64) float64 {
+	Du := jd - julian.J2000
+
+	// Split off the whole days, which are whole turns, to keep precision.
+	f := math.Mod(Du, 1.0)
+
+	return normalize360(360.0 * (f + 0.7790572732640 + 0.00273781191135448*Du))
+}
+
+// Greenwich mean sidereal time (GMST) in degrees, from the IAU 1982 expression
+// in UT1.
+//
+// jd: julian day (UT1).
+func GMST1982(jd float64) float64 {
+	T := (jd - julian.J2000) / 36525
+
+	theta := 280.46061837 + 360.98564736629*(jd-julian.J2000) +
+		T*T*(0.000387933-T/38710000)
+
+	return normalize360(theta)
+}
+
+// Greenwich mean sidereal time (GMST) in degrees, from the IAU 2006 expression
+// built on the Earth rotation angle.
+//
+// ut1: julian day (UT1).
+//
+// tt: julian day (TT).
+func GMST2006(ut1, tt float64) float64 {
+	t := (tt - julian.J2000) / 36525
+
+	// Accumulated precession in right ascension, in arcseconds.
+	p := 0.014506 + t*(4612.156534+t*(1.3915817+t*(-0.00000044+
+		t*(-0.000029956+t*(-0.0000000368)))))
+
+	return normalize360(EarthRotationAngle(ut1) + p/3600)
+}
+
+// Equation of the equinoxes is the difference between apparent and mean
+// sidereal time, in degrees: the nutation in longitude projected on the
+// equator, plus the complementary terms of the IAU 1994 resolution.
+//
+// tt: julian day (TT).
+func EquationOfEquinoxes(tt float64) float64 {
+	T := (tt - julian.J2000) / 36525
+	dpsi, _ := nutation.Nutation(tt)
+	e := nutation.TrueObliquity(tt)
+
+	// Longitude of the ascending node of the moon's orbit.
+	O := (125.04452 - 1934.136261*T) * RAD
+
+	return dpsi*math.Cos(e*RAD) +
+		(0.00264*math.Sin(O)+0.000063*math.Sin(2*O))/3600
+}
+
+// Greenwich apparent sidereal time (GAST) in degrees, the mean sidereal time
+// corrected for nutation.
+//
+// ut1: julian day (UT1).
+//
+// tt: julian day (TT).
+func GAST(ut1, tt float64) float64 {
+	return normalize360(GMST2006(ut1, tt) + EquationOfEquinoxes(tt))
+}
+
+// Local sidereal time in degrees, from Greenwich sidereal time.
+//
+// theta: Greenwich sidereal time.
+//
+// lon: longitude (west).
+func Local(theta, lon float64) float64 {
+	return normalize360(theta - lon)
+}
+
+// Polar motion moves the terrestrial pole the longitudes are measured from.
+// Returns the correction to add to a longitude (west) in degrees, for an
+// observer at latitude lat and the pole coordinates x and y in arcseconds.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func PolarMotionCorrection(lat, lon, x, y float64) float64 {
+	// The correction is defined for longitudes measured to the east.
+	dl := (x*math.Sin(-lon*RAD) + y*math.Cos(-lon*RAD)) * math.Tan(lat*RAD)
+
+	return -dl / 3600
+}
+
+// HMS is an angle expressed in hours, minutes and seconds of time.
+type HMS struct {
+	Hours   int
+	Minutes int
+	Seconds float64
+}
+
+// ToHMS transforms an angle in degrees into hours, minutes and seconds.
+func ToHMS(angle float64) HMS {
+	secs := normalize360(angle) / 15 * 3600
+
+	h := math.Floor(secs / 3600)
+	secs -= h * 3600
+	m := math.Floor(secs / 60)
+	secs -= m * 60
+
+	return HMS{int(h), int(m), secs}
+}
+
+// String formats the angle like 13h10m46.3668s.
+func (h HMS) String() string {
+	return fmt.Sprintf("%dh%02dm%07.4fs", h.Hours, h.Minutes, h.Seconds)
+}
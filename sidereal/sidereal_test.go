@@ -0,0 +1,107 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sidereal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the angle in degrees of a time given in hours, minutes and seconds.
+func hms(h, m int, s float64) float64 {
+	return (float64(h) + float64(m)/60 + s/3600) * 15
+}
+
+// GMST1982 tests, against Meeus examples 12.a and 12.b.
+func TestGMST1982(t *testing.T) {
+	tests := []struct {
+		name  string
+		jd    float64
+		theta float64
+	}{
+		{"Meeus12a", 2446895.5, hms(13, 10, 46.3668)},
+		{"Meeus12b", 2446896.30625, 128.7378734},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.theta, GMST1982(tt.jd), 1e-6)
+		})
+	}
+}
+
+// GMST2006 tests, against the IAU 1982 expression, which agrees to within a
+// few milliseconds of time around J2000.
+func TestGMST2006(t *testing.T) {
+	for _, jd := range []float64{2446895.5, 2451545.0, 2460310.75} {
+		tt := jd + 69.184/86400
+		assert.InDelta(t, GMST1982(jd), GMST2006(jd, tt), hms(0, 0, 0.005))
+	}
+}
+
+// EarthRotationAngle tests.
+func TestEarthRotationAngle(t *testing.T) {
+	// At J2000 the angle is its constant term.
+	assert.InDelta(t, 0.7790572732640*360, EarthRotationAngle(2451545.0), 1e-9)
+
+	// A sidereal day later the Earth has turned once more.
+	assert.InDelta(t, EarthRotationAngle(2451545.0),
+		EarthRotationAngle(2451545.0+1/1.00273781191135448), 1e-7)
+}
+
+// GAST tests, against Meeus example 12.a.
+func TestGAST(t *testing.T) {
+	// The mean sidereal time of the example uses the IAU 1982 expression.
+	jd := 2446895.5
+	ee := EquationOfEquinoxes(jd)
+	assert.InDelta(t, hms(0, 0, -0.2317), ee, hms(0, 0, 0.0002))
+	assert.InDelta(t, hms(13, 10, 46.1351), GMST1982(jd)+ee, hms(0, 0, 0.0002))
+	assert.InDelta(t, GMST2006(jd, jd)+ee, GAST(jd, jd), 1e-12)
+}
+
+// Local tests.
+func TestLocal(t *testing.T) {
+	assert.InDelta(t, 355.0, Local(10, 15), 1e-12)
+	assert.InDelta(t, 25.0, Local(10, -15), 1e-12)
+}
+
+// PolarMotionCorrection tests.
+func TestPolarMotionCorrection(t *testing.T) {
+	// No correction on the equator.
+	assert.Equal(t, 0.0, PolarMotionCorrection(0, 30, 0.1, 0.3))
+
+	// At 45° on the Greenwich meridian only y contributes, by y arcseconds.
+	assert.InDelta(t, -0.3/3600, PolarMotionCorrection(45, 0, 0.1, 0.3), 1e-12)
+}
+
+// HMS tests.
+func TestHMS(t *testing.T) {
+	tests := []struct {
+		name  string
+		angle float64
+		s     string
+	}{
+		{"Meeus12a", hms(13, 10, 46.3668), "13h10m46.3668s"},
+		{"Zero", 0, "0h00m00.0000s"},
+		{"Negative", -15, "23h00m00.0000s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.s, ToHMS(tt.angle).String())
+		})
+	}
+}
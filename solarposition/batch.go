@@ -0,0 +1,248 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Grid is the set of observers at every combination of a list of latitudes and
+// a list of longitudes, ordered by latitude and then by longitude, as the
+// pixels of a map are ordered by row and then by column.
+type Grid struct {
+	// Latitudes (north) and longitudes (west) in degrees.
+	Latitudes, Longitudes []float64
+
+	// Observer gives the elevation, the atmosphere and the horizon of every
+	// observer of the grid; its latitude and longitude are ignored.
+	Observer Observer
+}
+
+// Steps returns the values from start to end, both included, at a fixed step.
+// It is meant to build the axes of a Grid.
+//
+// start: first value.
+//
+// end: last value.
+//
+// step: difference between values, of the sign of end - start.
+func Steps(start, end, step float64) []float64 {
+	if step == 0 || (end-start)/step < 0 {
+		return []float64{start}
+	}
+
+	// Counting the steps first keeps the rounding from adding up, and lets
+	// the last value fall within a fraction of a step of end.
+	n := int((end-start)/step+1e-9) + 1
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = start + float64(i)*step
+	}
+
+	return values
+}
+
+// Len returns the number of observers of the grid.
+func (g Grid) Len() int {
+	return len(g.Latitudes) * len(g.Longitudes)
+}
+
+// At returns the observer at index i of the grid.
+func (g Grid) At(i int) Observer {
+	o := g.Observer
+	o.Latitude = g.Latitudes[i/len(g.Longitudes)]
+	o.Longitude = g.Longitudes[i%len(g.Longitudes)]
+
+	return o
+}
+
+// Observers returns the observers of the grid, in order.
+func (g Grid) Observers() []Observer {
+	observers := make([]Observer, g.Len())
+	for i := range observers {
+		observers[i] = g.At(i)
+	}
+
+	return observers
+}
+
+// BatchOptions controls how a batch is spread over goroutines.
+type BatchOptions struct {
+	// Workers is the number of goroutines running the batch, GOMAXPROCS by
+	// default.
+	Workers int
+
+	// Progress, if not nil, is called after each observer with the number of
+	// observers done and their total. The calls never overlap and done
+	// increases by one with every call.
+	Progress func(done, total int)
+}
+
+// Runs f for the indices 0 to n - 1 on a pool of workers and returns the
+// results in the order of the indices. The indices are handed out in order and
+// each one taken is completed, so the error returned, that of the lowest
+// index, is the one a loop would stop at. Cancelling ctx stops the workers
+// from taking new indices.
+func batch[T any](
+	ctx context.Context, n int, opt BatchOptions, f func(i int) (T, error),
+) ([]T, error) {
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]T, n)
+	errs := make([]error, n)
+
+	var (
+		mu, progress sync.Mutex
+		next, done   int
+		wg           sync.WaitGroup
+	)
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if next == n || ctx.Err() != nil {
+			return 0, false
+		}
+		next++
+
+		return next - 1, true
+	}
+	report := func() {
+		progress.Lock()
+		defer progress.Unlock()
+
+		done++
+		if opt.Progress != nil {
+			opt.Progress(done, n)
+		}
+	}
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				i, ok := take()
+				if !ok {
+					return
+				}
+
+				results[i], errs[i] = f(i)
+				if errs[i] != nil {
+					cancel()
+				}
+				report()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("observer %d: %w", i, err)
+		}
+	}
+
+	// Only the caller can have cancelled the batch without an error.
+	if next < n {
+		return nil, ctx.Err()
+	}
+
+	return results, nil
+}
+
+// Positions calculates the position of the sun seen by each observer at the
+// same instant, on a pool of workers. The coordinates which do not depend on
+// the observer, from the ecliptic longitude to the distance, are calculated
+// once for all of them. The positions are returned in the order of the
+// observers; on error or cancellation of ctx, no position is returned.
+//
+// ctx: context of the batch.
+//
+// jd: julian day (UT1).
+//
+// observers: observers.
+//
+// opt: options of the batch.
+func (s Sky) Positions(
+	ctx context.Context, jd float64, observers []Observer, opt BatchOptions,
+) ([]Position, error) {
+	p, err := s.instant(jd)
+	if err != nil {
+		return nil, err
+	}
+	x, y := s.pole(jd)
+
+	return batch(ctx, len(observers), opt, func(i int) (Position, error) {
+		if err := observers[i].Validate(); err != nil {
+			return Position{}, err
+		}
+
+		return p.at(s.Body, observers[i], x, y)
+	})
+}
+
+// DayInfos computes the DayInfo of each observer for the julian day, on a pool
+// of workers. Polar days and nights are recorded in each DayInfo as by
+// DayInfo. Unlike Positions, nothing is shared between the observers: their
+// transits and crossings fall at instants of their own, at which the right
+// ascension and declination of the sun are calculated anew, so a batch costs
+// as much as the DayInfo calls it replaces, spread over the workers. The
+// results are returned in the order of the observers; on error or
+// cancellation of ctx, no result is returned.
+//
+// ctx: context of the batch.
+//
+// jd: julian day (UT1).
+//
+// observers: observers.
+//
+// opt: options of the batch.
+func (s Sky) DayInfos(
+	ctx context.Context, jd float64, observers []Observer, opt BatchOptions,
+) ([]DayInfo, error) {
+	if _, err := s.Body.parameters(); err != nil {
+		return nil, err
+	}
+
+	return batch(ctx, len(observers), opt, func(i int) (DayInfo, error) {
+		return s.DayInfo(jd, observers[i])
+	})
+}
+
+// Positions is Sky.Positions on the analytic ephemeris.
+func (b Body) Positions(
+	ctx context.Context, jd float64, observers []Observer, opt BatchOptions,
+) ([]Position, error) {
+	return b.With(Analytic).Positions(ctx, jd, observers, opt)
+}
+
+// DayInfos is Sky.DayInfos on the analytic ephemeris.
+func (b Body) DayInfos(
+	ctx context.Context, jd float64, observers []Observer, opt BatchOptions,
+) ([]DayInfo, error) {
+	return b.With(Analytic).DayInfos(ctx, jd, observers, opt)
+}
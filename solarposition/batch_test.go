@@ -0,0 +1,164 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Steps tests.
+func TestSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    float64
+		end      float64
+		step     float64
+		expected []float64
+	}{
+		{"Up", -90, 90, 45, []float64{-90, -45, 0, 45, 90}},
+		{"Down", 60, 59.7, -0.1, []float64{60, 59.9, 59.8, 59.7}},
+		{"Short", 0, 1, 0.3, []float64{0, 0.3, 0.6, 0.9}},
+		{"Single", 5, 5, 1, []float64{5}},
+		{"WrongWay", 0, 10, -1, []float64{0}},
+		{"ZeroStep", 0, 10, 0, []float64{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDeltaSlice(t, tt.expected, Steps(tt.start, tt.end, tt.step), 1e-12)
+		})
+	}
+}
+
+// Grid tests: the observers are ordered by latitude, then by longitude.
+func TestGrid(t *testing.T) {
+	g := Grid{[]float64{10, 20}, []float64{-5, 0, 5}, Observer{Elevation: 100}}
+	assert.Equal(t, 6, g.Len())
+
+	observers := g.Observers()
+	assert.Len(t, observers, 6)
+	assert.Equal(t, 10.0, observers[2].Latitude)
+	assert.Equal(t, 5.0, observers[2].Longitude)
+	assert.Equal(t, 20.0, observers[3].Latitude)
+	assert.Equal(t, -5.0, observers[3].Longitude)
+	assert.Equal(t, 100.0, observers[5].Elevation)
+}
+
+// Positions tests: the batch matches Position for each observer, whatever the
+// number of workers.
+func TestPositions(t *testing.T) {
+	g := Grid{Steps(-80, 80, 20), Steps(-180, 170, 10), NewObserver(0, 0)}
+	observers := g.Observers()
+
+	for _, workers := range []int{0, 1, 7} {
+		calls, last := 0, 0
+		opt := BatchOptions{Workers: workers, Progress: func(done, total int) {
+			calls++
+			assert.Equal(t, calls, done)
+			assert.Equal(t, len(observers), total)
+			last = done
+		}}
+
+		positions, err := Earth.Positions(context.Background(), 2453097.25, observers, opt)
+		assert.NoError(t, err)
+		assert.Len(t, positions, len(observers))
+		assert.Equal(t, len(observers), last)
+
+		for i, o := range observers {
+			expected, _ := Earth.Position(2453097.25, o)
+			assert.InDelta(t, expected.Azimuth, positions[i].Azimuth, 1e-9)
+			assert.InDelta(t, expected.ApparentAltitude, positions[i].ApparentAltitude, 1e-9)
+			assert.InDelta(t, expected.HourAngle, positions[i].HourAngle, 1e-9)
+			assert.Equal(t, expected.Declination, positions[i].Declination)
+		}
+	}
+
+	positions, err := Earth.Positions(context.Background(), 2453097.25, nil, BatchOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+// Positions tests: errors and cancellation.
+func TestPositionsErrors(t *testing.T) {
+	observers := Grid{Steps(0, 50, 10), Steps(0, 50, 10), NewObserver(0, 0)}.Observers()
+	observers[9].Longitude = math.NaN()
+	observers[20].Latitude = 91
+
+	for range 10 {
+		positions, err := Earth.Positions(context.Background(), 2453097.25, observers, BatchOptions{Workers: 4})
+		assert.ErrorIs(t, err, ErrOutOfRange)
+		assert.ErrorContains(t, err, "observer 9:")
+		assert.Nil(t, positions)
+	}
+
+	_, err := Body(23).Positions(context.Background(), 2453097.25, observers, BatchOptions{})
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	positions, err := Earth.Positions(ctx, 2453097.25, observers[:5], BatchOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, positions)
+}
+
+// DayInfos tests: the batch matches DayInfo for each observer, polar days and
+// nights included, and stops when cancelled.
+func TestDayInfos(t *testing.T) {
+	observers := Grid{Steps(-85, 85, 17), Steps(-150, 150, 100), NewObserver(0, 0)}.Observers()
+
+	infos, err := Earth.DayInfos(context.Background(), 2453177.0, observers, BatchOptions{Workers: 3})
+	assert.NoError(t, err)
+	assert.Len(t, infos, len(observers))
+	for i, o := range observers {
+		expected, _ := Earth.DayInfo(2453177.0, o)
+		assert.Equal(t, expected, infos[i])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := BatchOptions{Workers: 2, Progress: func(done, total int) {
+		if done == 3 {
+			cancel()
+		}
+	}}
+	infos, err = Earth.DayInfos(ctx, 2453177.0, observers, opt)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, infos)
+
+	_, err = Body(23).DayInfos(context.Background(), 2453177.0, observers, BatchOptions{})
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
+
+// Batch benchmarks: positions over a one degree grid of the world, one by one
+// and in a batch.
+func BenchmarkPositionGrid(b *testing.B) {
+	observers := Grid{Steps(-90, 90, 1), Steps(-180, 179, 1), NewObserver(0, 0)}.Observers()
+	for i := 0; i < b.N; i++ {
+		for _, o := range observers {
+			_, _ = Earth.Position(2453097.25, o)
+		}
+	}
+}
+
+func BenchmarkPositions(b *testing.B) {
+	observers := Grid{Steps(-90, 90, 1), Steps(-180, 179, 1), NewObserver(0, 0)}.Observers()
+	for i := 0; i < b.N; i++ {
+		_, _ = Earth.Positions(context.Background(), 2453097.25, observers, BatchOptions{})
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Body is a planet of the Solar system from which the position of the sun is
+// observed. The numeric values match the planet enumeration (see README).
+type Body int
+
+const (
+	Mercury Body = iota
+	Venus
+	Earth
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+	Pluto
+)
+
+// parameters holds everything needed to calculate the position of the sun as
+// seen from a body. Adding a body only requires a new entry in the table.
+type parameters struct {
+	name string
+
+	// MeanAnomaly
+	M0, M1 float64
+
+	// ObliquityEcliptic
+	E float64
+
+	// PerihelionLongitude
+	P float64
+
+	// EquationOfCenter
+	C [6]float64
+
+	// SiderealTime
+	T0, T1 float64
+
+	// SolarTransit, coefficient of sin(2l) in degrees. The approximation only
+	// holds for small obliquities, so it is left at zero for bodies tilted
+	// close to or beyond 90 degrees and the refinement does the rest.
+	A2 float64
+
+	// Sunrise/Sunset
+	h_0, d_Sun float64
+
+	// Observer
+	R float64
+
+	// Distance
+	A, Ecc float64
+
+	// Pole
+	PoleA, PoleD float64
+}
+
+var bodies = [...]parameters{
+	Mercury: {
+		name: "Mercury",
+		M0:   M0Mercury,
+		M1:   M1Mercury,
+		E:    EMercury,
+		P:    PMercury,
+		C: [6]float64{
+			C1Mercury, C2Mercury, C3Mercury,
+			C4Mercury, C5Mercury, C6Mercury,
+		},
+		T0:    T0Mercury,
+		T1:    T1Mercury,
+		A2:    0,
+		h_0:   h_0Mercury,
+		d_Sun: d_SunMercury,
+		R:     RMercury,
+		A:     AMercury,
+		Ecc:   EccMercury,
+		PoleA: PoleAMercury,
+		PoleD: PoleDMercury,
+	},
+	Venus: {
+		name: "Venus",
+		M0:   M0Venus,
+		M1:   M1Venus,
+		E:    EVenus,
+		P:    PVenus,
+		C: [6]float64{
+			C1Venus, C2Venus, C3Venus,
+			C4Venus, C5Venus, C6Venus,
+		},
+		T0:    T0Venus,
+		T1:    T1Venus,
+		A2:    -0.0304,
+		h_0:   h_0Venus,
+		d_Sun: d_SunVenus,
+		R:     RVenus,
+		A:     AVenus,
+		Ecc:   EccVenus,
+		PoleA: PoleAVenus,
+		PoleD: PoleDVenus,
+	},
+	Earth: {
+		name: "Earth",
+		M0:   M0Earth,
+		M1:   M1Earth,
+		E:    EEarth,
+		P:    PEarth,
+		C: [6]float64{
+			C1Earth, C2Earth, C3Earth,
+			C4Earth, C5Earth, C6Earth,
+		},
+		T0:    T0Earth,
+		T1:    T1Earth,
+		A2:    -2.4657,
+		h_0:   h_0Earth,
+		d_Sun: d_SunEarth,
+		R:     REarth,
+		A:     AEarth,
+		Ecc:   EccEarth,
+		PoleA: PoleAEarth,
+		PoleD: PoleDEarth,
+	},
+	Mars: {
+		name: "Mars",
+		M0:   M0Mars,
+		M1:   M1Mars,
+		E:    EMars,
+		P:    PMars,
+		C: [6]float64{
+			C1Mars, C2Mars, C3Mars,
+			C4Mars, C5Mars, C6Mars,
+		},
+		T0:    T0Mars,
+		T1:    T1Mars,
+		A2:    -2.8608,
+		h_0:   h_0Mars,
+		d_Sun: d_SunMars,
+		R:     RMars,
+		A:     AMars,
+		Ecc:   EccMars,
+		PoleA: PoleAMars,
+		PoleD: PoleDMars,
+	},
+	Jupiter: {
+		name: "Jupiter",
+		M0:   M0Jupiter,
+		M1:   M1Jupiter,
+		E:    EJupiter,
+		P:    PJupiter,
+		C: [6]float64{
+			C1Jupiter, C2Jupiter, C3Jupiter,
+			C4Jupiter, C5Jupiter, C6Jupiter,
+		},
+		T0:    T0Jupiter,
+		T1:    T1Jupiter,
+		A2:    -2.8608,
+		h_0:   h_0Jupiter,
+		d_Sun: d_SunJupiter,
+		R:     RJupiter,
+		A:     AJupiter,
+		Ecc:   EccJupiter,
+		PoleA: PoleAJupiter,
+		PoleD: PoleDJupiter,
+	},
+	Saturn: {
+		name: "Saturn",
+		M0:   M0Saturn,
+		M1:   M1Saturn,
+		E:    ESaturn,
+		P:    PSaturn,
+		C: [6]float64{
+			C1Saturn, C2Saturn, C3Saturn,
+			C4Saturn, C5Saturn, C6Saturn,
+		},
+		T0:    T0Saturn,
+		T1:    T1Saturn,
+		A2:    -2.8608,
+		h_0:   h_0Saturn,
+		d_Sun: d_SunSaturn,
+		R:     RSaturn,
+		A:     ASaturn,
+		Ecc:   EccSaturn,
+		PoleA: PoleASaturn,
+		PoleD: PoleDSaturn,
+	},
+	Uranus: {
+		name: "Uranus",
+		M0:   M0Uranus,
+		M1:   M1Uranus,
+		E:    EUranus,
+		P:    PUranus,
+		C: [6]float64{
+			C1Uranus, C2Uranus, C3Uranus,
+			C4Uranus, C5Uranus, C6Uranus,
+		},
+		T0:    T0Uranus,
+		T1:    T1Uranus,
+		A2:    0,
+		h_0:   h_0Uranus,
+		d_Sun: d_SunUranus,
+		R:     RUranus,
+		A:     AUranus,
+		Ecc:   EccUranus,
+		PoleA: PoleAUranus,
+		PoleD: PoleDUranus,
+	},
+	Neptune: {
+		name: "Neptune",
+		M0:   M0Neptune,
+		M1:   M1Neptune,
+		E:    ENeptune,
+		P:    PNeptune,
+		C: [6]float64{
+			C1Neptune, C2Neptune, C3Neptune,
+			C4Neptune, C5Neptune, C6Neptune,
+		},
+		T0:    T0Neptune,
+		T1:    T1Neptune,
+		A2:    -3.5216,
+		h_0:   h_0Neptune,
+		d_Sun: d_SunNeptune,
+		R:     RNeptune,
+		A:     ANeptune,
+		Ecc:   EccNeptune,
+		PoleA: PoleANeptune,
+		PoleD: PoleDNeptune,
+	},
+	Pluto: {
+		name: "Pluto",
+		M0:   M0Pluto,
+		M1:   M1Pluto,
+		E:    EPluto,
+		P:    PPluto,
+		C: [6]float64{
+			C1Pluto, C2Pluto, C3Pluto,
+			C4Pluto, C5Pluto, C6Pluto,
+		},
+		T0:    T0Pluto,
+		T1:    T1Pluto,
+		A2:    0,
+		h_0:   h_0Pluto,
+		d_Sun: d_SunPluto,
+		R:     RPluto,
+		A:     APluto,
+		Ecc:   EccPluto,
+		PoleA: PoleAPluto,
+		PoleD: PoleDPluto,
+	},
+}
+
+// Bodies returns all supported bodies in enumeration order.
+func Bodies() []Body {
+	bs := make([]Body, len(bodies))
+	for i := range bodies {
+		bs[i] = Body(i)
+	}
+
+	return bs
+}
+
+// Returns the parameter table entry of the body.
+func (b Body) parameters() (*parameters, error) {
+	if !b.Valid() {
+		return nil, ErrInvalidEnum
+	}
+
+	return &bodies[b], nil
+}
+
+// Valid reports whether the body is supported.
+func (b Body) Valid() bool {
+	return b >= 0 && int(b) < len(bodies)
+}
+
+// String returns the name of the body.
+func (b Body) String() string {
+	if !b.Valid() {
+		return "Body(" + strconv.Itoa(int(b)) + ")"
+	}
+
+	return bodies[b].name
+}
+
+// ParseBody returns the body with the given name, ignoring case.
+func ParseBody(name string) (Body, error) {
+	for i := range bodies {
+		if strings.EqualFold(bodies[i].name, name) {
+			return Body(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalidEnum, name)
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the body by name.
+func (b Body) MarshalText() ([]byte, error) {
+	if !b.Valid() {
+		return nil, ErrInvalidEnum
+	}
+
+	return []byte(bodies[b].name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the body from
+// its name.
+func (b *Body) UnmarshalText(text []byte) error {
+	v, err := ParseBody(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = v
+
+	return nil
+}
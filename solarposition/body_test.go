@@ -0,0 +1,129 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// String tests.
+func TestBodyString(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Body
+		s    string
+	}{
+		{"ForMercury", Mercury, "Mercury"},
+		{"ForEarth", Earth, "Earth"},
+		{"ForSaturn", Saturn, "Saturn"},
+		{"InvalidBody", Body(12), "Body(12)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.s, tt.b.String())
+		})
+	}
+}
+
+// ParseBody tests.
+func TestParseBody(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		b    Body
+		err  error
+	}{
+		{"ForEarth", "Earth", Earth, nil},
+		{"ForMarsLowerCase", "mars", Mars, nil},
+		{"ForJupiterUpperCase", "JUPITER", Jupiter, nil},
+		{"InvalidBody", "Vulcan", 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ParseBody(tt.s)
+			assert.Equal(t, tt.b, b)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+// JSON marshaling tests.
+func TestBodyJSON(t *testing.T) {
+	type observation struct {
+		Body Body `json:"body"`
+	}
+
+	bs, err := json.Marshal(observation{Body: Venus})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"body":"Venus"}`, string(bs))
+
+	var o observation
+	err = json.Unmarshal([]byte(`{"body":"saturn"}`), &o)
+	assert.NoError(t, err)
+	assert.Equal(t, Saturn, o.Body)
+
+	err = json.Unmarshal([]byte(`{"body":"Vulcan"}`), &o)
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+
+	_, err = json.Marshal(observation{Body: Body(12)})
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
+
+// Bodies tests.
+func TestBodies(t *testing.T) {
+	bs := Bodies()
+	assert.Equal(t, []Body{
+		Mercury, Venus, Earth, Mars, Jupiter, Saturn, Uranus, Neptune, Pluto,
+	}, bs)
+	for i, b := range bs {
+		assert.Equal(t, i, int(b))
+		assert.True(t, b.Valid())
+	}
+	assert.False(t, Body(-1).Valid())
+}
+
+// Body method tests.
+func TestBodyMethods(t *testing.T) {
+	tests := []struct {
+		name   string
+		b      Body
+		lat    float64
+		lon    float64
+		M      float64
+		J_rise float64
+		err    error
+	}{
+		{"ForEarth", Earth, 52, -5.0, 87.18073456000002, 2.453096719211567e+06, nil},
+		{"ForMars", Mars, -14.6, 184.6, 112.65309536000007, 2.453096686040873e+06, nil},
+		{"InvalidBody", Body(23), 12, -45, 0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			M, err := tt.b.MeanAnomaly(2453097.0)
+			assert.Equal(t, tt.M, M)
+			assert.Equal(t, tt.err, err)
+
+			J_rise, err := tt.b.SunriseTime(2453097.0, tt.lat, tt.lon)
+			assert.Equal(t, tt.J_rise, J_rise)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
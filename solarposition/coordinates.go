@@ -0,0 +1,130 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"fmt"
+	"math"
+)
+
+// RangeError reports an input that is out of range, naming the quantity, the
+// value and the range it should be in. It matches ErrOutOfRange with errors.Is.
+type RangeError struct {
+	Name   string
+	Value  float64
+	Reason string
+}
+
+// Error returns a description of the invalid input.
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%s %v out of range: %s", e.Name, e.Value, e.Reason)
+}
+
+// Unwrap returns ErrOutOfRange.
+func (e *RangeError) Unwrap() error {
+	return ErrOutOfRange
+}
+
+// Returns a RangeError when the latitude is not between -90° and 90°.
+func checkLatitude(lat float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return &RangeError{"latitude", lat, "must be between -90° and 90°"}
+	}
+
+	return nil
+}
+
+// Returns a RangeError when the longitude is not a finite number. Longitudes
+// beyond ±180° are accepted and wrap around the globe on purpose: the
+// longitudes of the other planets are measured west from 0° to 360° (IAU
+// planetographic, such as 184.6° on Mars), and the sidereal time only needs
+// the longitude modulo 360°. NormalizeLongitude brings a longitude into the
+// range of the Earth.
+func checkLongitude(lon float64) error {
+	if math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return &RangeError{"longitude", lon, "must be a finite number"}
+	}
+
+	return nil
+}
+
+// NormalizeLongitude wraps a longitude into the range -180° (exclusive) to 180°
+// (inclusive), whichever direction it is measured in.
+func NormalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon, 360.0)
+	switch {
+	case lon > 180:
+		lon -= 360
+	case lon <= -180:
+		lon += 360
+	}
+
+	return lon
+}
+
+// WestLongitude converts an east-positive longitude, as used by GPS, WGS84 and
+// GeoJSON, into the west-positive longitude taken by this package.
+func WestLongitude(east float64) float64 {
+	return NormalizeLongitude(-east)
+}
+
+// EastLongitude converts a west-positive longitude, as taken by this package,
+// into the usual east-positive longitude.
+func EastLongitude(west float64) float64 {
+	return NormalizeLongitude(-west)
+}
+
+// NewObserverWGS84 returns an observer at sea level in the standard atmosphere
+// from the usual WGS84 coordinates, with longitudes positive to the east. A
+// RangeError is returned for a latitude beyond ±90° or a longitude that is not
+// a number.
+//
+// lat: latitude (north)
+//
+// lon: longitude (east).
+func NewObserverWGS84(lat, lon float64) (Observer, error) {
+	if err := checkLatitude(lat); err != nil {
+		return Observer{}, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return Observer{}, err
+	}
+
+	return NewObserver(lat, WestLongitude(lon)), nil
+}
+
+// Validate returns a RangeError for the first field of the observer that is
+// out of range.
+func (o Observer) Validate() error {
+	if err := checkLatitude(o.Latitude); err != nil {
+		return err
+	}
+	if err := checkLongitude(o.Longitude); err != nil {
+		return err
+	}
+
+	switch {
+	case math.IsNaN(o.Elevation) || math.IsInf(o.Elevation, 0):
+		return &RangeError{"elevation", o.Elevation, "must be a finite number"}
+	case !(o.Pressure >= 0) || math.IsInf(o.Pressure, 0):
+		return &RangeError{"pressure", o.Pressure, "must be zero or more"}
+	case !(o.Temperature > -273.15) || math.IsInf(o.Temperature, 0):
+		return &RangeError{
+			"temperature", o.Temperature, "must be above absolute zero",
+		}
+	}
+
+	return nil
+}
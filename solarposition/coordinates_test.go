@@ -0,0 +1,179 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// NormalizeLongitude tests.
+func TestNormalizeLongitude(t *testing.T) {
+	tests := []struct {
+		name     string
+		lon      float64
+		expected float64
+	}{
+		{"InRange", -74.006, -74.006},
+		{"East", 355, -5},
+		{"West", -190, 170},
+		{"Turns", 725, 5},
+		{"Antimeridian", -180, 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, NormalizeLongitude(tt.lon), 1e-12)
+		})
+	}
+}
+
+// WestLongitude and EastLongitude tests.
+func TestLongitudeConversion(t *testing.T) {
+	assert.Equal(t, 74.006, WestLongitude(-74.006))
+	assert.Equal(t, -5.0, WestLongitude(365))
+	assert.Equal(t, -74.006, EastLongitude(74.006))
+	assert.Equal(t, 139.6917, EastLongitude(WestLongitude(139.6917)))
+}
+
+// NewObserverWGS84 tests.
+func TestNewObserverWGS84(t *testing.T) {
+	tests := []struct {
+		name string
+		lat  float64
+		lon  float64
+		west float64
+		err  string
+	}{
+		{"NewYork", 40.7128, -74.006, 74.006, ""},
+		{"Tokyo", 35.6764, 139.65, -139.65, ""},
+		{"Wrapped", 52, 365, -5.0, ""},
+		{"LatitudeTooLarge", 95, 5, 0,
+			"latitude 95 out of range: must be between -90° and 90°"},
+		{"LatitudeNaN", math.NaN(), 5, 0,
+			"latitude NaN out of range: must be between -90° and 90°"},
+		{"LongitudeInf", 52, math.Inf(1), 0,
+			"longitude +Inf out of range: must be a finite number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewObserverWGS84(tt.lat, tt.lon)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.ErrorIs(t, err, ErrOutOfRange)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.lat, o.Latitude)
+			assert.InDelta(t, tt.west, o.Longitude, 1e-12)
+			assert.Equal(t, standardPressure, o.Pressure)
+		})
+	}
+}
+
+// Observer.Validate tests.
+func TestObserverValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		o     Observer
+		field string
+	}{
+		{"Valid", NewObserver(52, -5.0), ""},
+		{"FarLongitude", NewObserver(52, 545), ""},
+		{"Latitude", NewObserver(-91, 5), "latitude"},
+		{"Longitude", NewObserver(52, math.NaN()), "longitude"},
+		{"Elevation", Observer{52, 5, math.Inf(1), 1010, 10, Bennett, nil}, "elevation"},
+		{"Pressure", Observer{52, 5, 0, -1, 10, Bennett, nil}, "pressure"},
+		{"Temperature", Observer{52, 5, 0, 1010, -300, Bennett, nil}, "temperature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.o.Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var re *RangeError
+			assert.True(t, errors.As(err, &re))
+			assert.Equal(t, tt.field, re.Name)
+			assert.ErrorIs(t, err, ErrOutOfRange)
+		})
+	}
+}
+
+// Coordinate validation tests: the calculations reject invalid coordinates
+// and wrap longitudes beyond ±180°.
+func TestCoordinateValidation(t *testing.T) {
+	_, err := Earth.SunriseTime(2453097.0, 95, -5.0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	_, err = Earth.Altitude(2453097.0, -90.5, -5.0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	_, err = Earth.TransitTime(2453097.0, math.NaN())
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	_, err = Earth.With(VSOP87).HourAngle(2453097.0, math.Inf(-1))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	_, err = Earth.SunsetTimeAt(2453097.0, NewObserver(52, math.NaN()))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+
+	expected, _ := Earth.SunriseTime(2453097.0, 52, -5.0)
+	actual, err := Earth.SunriseTime(2453097.0, 52, 355.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected, actual, 1e-8)
+
+	// The antimeridian is the same from either side, and longitudes just past
+	// it carry on around the globe.
+	tests := []struct {
+		name     string
+		lon      float64
+		expected float64
+	}{
+		{"East", -180, 180},
+		{"West", 180, 180},
+		{"PastWest", 180.5, -179.5},
+		{"PastEast", -180.5, 179.5},
+		{"FullTurn", 360, 0},
+		{"Planetographic", 184.6, -175.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, _ := Earth.SunriseTime(2453097.0, 52, tt.expected)
+			actual, err := Earth.SunriseTime(2453097.0, 52, tt.lon)
+			assert.NoError(t, err)
+			assert.InDelta(t, expected, actual, 1e-8)
+
+			expected, _ = Mars.HourAngle(2453097.0, tt.expected)
+			actual, err = Mars.HourAngle(2453097.0, tt.lon)
+			assert.NoError(t, err)
+			assert.InDelta(t, 0, math.Remainder(expected-actual, 360), 1e-8)
+		})
+	}
+
+	o, _ := NewObserverWGS84(52, 5.0)
+	actual, err = Earth.SunriseTimeAt(2453097.0, o)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected, actual, 10.0/86400)
+}
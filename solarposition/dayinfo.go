@@ -0,0 +1,163 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Period is the part of a day during which the sun is above an altitude, from
+// its crossing going up to its crossing going down.
+type Period struct {
+	// Julian days (UT1) of the crossings, zero when Err is set.
+	Start, End float64
+
+	// Length of the period, a whole solar day under the midnight sun and zero
+	// in the polar night.
+	Length time.Duration
+
+	// ErrPolarNight or ErrMidnightSun when the sun does not cross the altitude
+	// on that day.
+	Err error
+}
+
+// DayInfo sums up the day of an observer around its transit.
+type DayInfo struct {
+	// Julian day (UT1) of the transit.
+	Transit float64
+
+	// Altitude (in degrees) of the sun at the transit.
+	NoonAltitude float64
+
+	// Daylight lasts from sunrise to sunset.
+	Daylight Period
+
+	// Twilights last from dawn to dusk, indexed by kind of twilight.
+	Twilights [3]Period
+
+	// Night is the rest of the solar day after the daylight.
+	Night time.Duration
+
+	// DaylightChange is the daylight gained since the previous day, negative
+	// when it was lost.
+	DaylightChange time.Duration
+}
+
+// Returns the period between the crossings of altitude h_0 on either side of
+// the transit at julian day J, at which the sun is at hour angle H and
+// declination d. Polar days and nights are recorded in the period, other
+// errors are returned.
+func (s Sky) period(
+	J, H, d float64, h_0 func(A float64) float64, day time.Duration, o Observer,
+) (Period, error) {
+	rise, err := s.crossingFrom(J, H, d, h_0, Rising, o.Latitude, o.Longitude)
+	if err == nil {
+		var set Root
+		set, err = s.crossingFrom(J, H, d, h_0, Setting, o.Latitude, o.Longitude)
+		if err == nil {
+			return Period{rise.JD, set.JD, days(set.JD - rise.JD), nil}, nil
+		}
+	}
+
+	switch {
+	case errors.Is(err, ErrPolarNight):
+		return Period{Err: err}, nil
+	case errors.Is(err, ErrMidnightSun):
+		return Period{Length: day, Err: err}, nil
+	default:
+		return Period{}, err
+	}
+}
+
+// Converts a number of days into a duration.
+func days(d float64) time.Duration {
+	return time.Duration(math.Round(d * float64(24*time.Hour)))
+}
+
+// DayInfo computes the transit, the noon altitude, the daylight between sunrise
+// and sunset and the twilights of the solar day around the julian day. The
+// transit and the position of the sun at the transit are calculated once, and
+// give every crossing its first guess. Sunrise and sunset take into account the
+// elevation and the atmosphere of the observer.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) DayInfo(jd float64, o Observer) (DayInfo, error) {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return DayInfo{}, err
+	}
+
+	horizon, err := s.horizon(o)
+	if err != nil {
+		return DayInfo{}, err
+	}
+
+	J3 := math.Abs(bp.solarDay())
+	day := days(J3)
+
+	var info DayInfo
+	info.Transit, err = s.TransitTime(jd, o.Longitude)
+	if err != nil {
+		return DayInfo{}, err
+	}
+
+	H, d, err := s.hourAngle(info.Transit, o.Latitude, o.Longitude)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	info.NoonAltitude = altitude(H, d, o.Latitude)
+
+	info.Daylight, err = s.period(info.Transit, H, d, horizon, day, o)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	info.Night = day - info.Daylight.Length
+
+	for _, t := range []Twilight{Civil, Nautical, Astronomical} {
+		h, _ := t.Altitude()
+		h_0 := func(float64) float64 { return h }
+
+		info.Twilights[t], err = s.period(info.Transit, H, d, h_0, day, o)
+		if err != nil {
+			return DayInfo{}, err
+		}
+	}
+
+	// The previous day only needs its daylight.
+	J, err := s.TransitTime(info.Transit-J3, o.Longitude)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	H, d, err = s.hourAngle(J, o.Latitude, o.Longitude)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	prev, err := s.period(J, H, d, horizon, day, o)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	info.DaylightChange = info.Daylight.Length - prev.Length
+
+	return info, nil
+}
+
+// DayInfo is Sky.DayInfo on the analytic ephemeris.
+func (b Body) DayInfo(jd float64, o Observer) (DayInfo, error) {
+	return b.With(Analytic).DayInfo(jd, o)
+}
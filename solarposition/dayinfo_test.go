@@ -0,0 +1,111 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// DayInfo tests: the summary agrees with the individual event times.
+func TestDayInfo(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	info, err := Earth.DayInfo(2453097.0, o)
+	assert.NoError(t, err)
+
+	J_transit, _ := Earth.TransitTime(2453097.0, -5.0)
+	assert.Equal(t, J_transit, info.Transit)
+	h, _ := Earth.Altitude(J_transit, 52, -5.0)
+	assert.Equal(t, h, info.NoonAltitude)
+
+	J_rise, _ := Earth.SunriseTimeAt(2453097.0, o)
+	J_set, _ := Earth.SunsetTimeAt(2453097.0, o)
+	assert.Equal(t, Period{J_rise, J_set, days(J_set - J_rise), nil}, info.Daylight)
+
+	for _, tw := range []Twilight{Civil, Nautical, Astronomical} {
+		J_dawn, _ := Earth.DawnTime(2453097.0, tw, 52, -5.0)
+		J_dusk, _ := Earth.DuskTime(2453097.0, tw, 52, -5.0)
+		assert.Equal(t, J_dawn, info.Twilights[tw].Start)
+		assert.Equal(t, J_dusk, info.Twilights[tw].End)
+	}
+
+	assert.InDelta(t, 12*time.Hour+59*time.Minute+44*time.Second, info.Daylight.Length, float64(time.Second))
+	assert.InDelta(t, 24*time.Hour, info.Daylight.Length+info.Night, float64(time.Second))
+	assert.InDelta(t, 4*time.Minute, info.DaylightChange, float64(time.Second))
+}
+
+// DayInfo tests: the crossings share the position of the sun at the transit,
+// which is looked up once more by the refinement of the transit itself.
+func TestDayInfoShared(t *testing.T) {
+	var jde float64
+	n := 0
+	sky := Earth.With(EphemerisFunc(func(b Body, jd float64) (float64, float64, error) {
+		if jd == jde {
+			n++
+		}
+
+		return Analytic.Equatorial(b, jd)
+	}))
+
+	J_transit, _ := sky.TransitTime(2453097.0, -5.0)
+	jde = Sky{}.dynamicalTime(J_transit)
+	info, err := sky.DayInfo(2453097.0, NewObserver(52, -5.0))
+	assert.NoError(t, err)
+	assert.Equal(t, J_transit, info.Transit)
+	assert.Equal(t, 2, n)
+}
+
+// DayInfo tests: days on which the sun does not cross some altitudes.
+func TestDayInfoPolar(t *testing.T) {
+	tests := []struct {
+		name     string
+		jd       float64
+		b        Body
+		lat      float64
+		daylight error
+		twilight [3]error
+		length   time.Duration
+		change   time.Duration
+	}{
+		{"Spring", 2453097.0, Earth, 52, nil, [3]error{}, 12*time.Hour + 59*time.Minute + 44*time.Second, 4 * time.Minute},
+		{"MidnightSun", 2453177.5, Earth, 70, ErrMidnightSun, [3]error{ErrMidnightSun, ErrMidnightSun, ErrMidnightSun}, 24 * time.Hour, 0},
+		{"PolarNight", 2453360.0, Earth, 80, ErrPolarNight, [3]error{ErrPolarNight, ErrPolarNight, nil}, 0, 0},
+		{"Mars", 2453097.0, Mars, 52, nil, [3]error{}, 13*time.Hour + 21*time.Minute + 14*time.Second, 2*time.Minute + 12*time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := tt.b.DayInfo(tt.jd, NewObserver(tt.lat, -5.0))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.daylight, info.Daylight.Err)
+			for i, err := range tt.twilight {
+				assert.Equal(t, err, info.Twilights[i].Err)
+			}
+			assert.InDelta(t, tt.length, info.Daylight.Length, float64(time.Second))
+			assert.InDelta(t, tt.change, info.DaylightChange, float64(time.Second))
+		})
+	}
+}
+
+// DayInfo tests.
+func TestDayInfoErrors(t *testing.T) {
+	_, err := Body(23).DayInfo(2453097.0, NewObserver(52, -5.0))
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+
+	_, err = Earth.DayInfo(2453097.0, NewObserver(95, -5.0))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
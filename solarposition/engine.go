@@ -0,0 +1,58 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+// Engine calculates the horizontal coordinates of the sun, so callers can
+// choose between speed and accuracy per workload. Body is the fast analytic
+// engine, Sky the same calculations on another ephemeris; spa.SPA implements
+// the NREL Solar Position Algorithm for the Earth.
+type Engine interface {
+	// Horizontal returns the azimuth (A), measured from the south and between
+	// −180° and 180°, and the altitude (h) of the sun.
+	//
+	// jd: julian day (UT1).
+	//
+	// lat: latitude (north)
+	//
+	// lon: longitude (west).
+	Horizontal(jd float64, lat, lon float64) (float64, float64, error)
+}
+
+// Horizontal coordinates (A, h) are the azimuth and the geometric altitude of
+// the sun, as returned by Azimuth and Altitude, from a single call to the
+// ephemeris.
+//
+// jd: julian day (UT1).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) Horizontal(jd float64, lat, lon float64) (float64, float64, error) {
+	if err := checkLatitude(lat); err != nil {
+		return 0, 0, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, 0, err
+	}
+
+	H, d, err := s.hourAngle(jd, lat, lon)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	A, h := azimuth(H, d, lat), altitude(H, d, lat)
+
+	return A, h, nil
+}
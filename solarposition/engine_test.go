@@ -0,0 +1,60 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"testing"
+
+	"github.com/codymj/celestia/spa"
+	"github.com/stretchr/testify/assert"
+)
+
+// Horizontal tests.
+func TestHorizontal(t *testing.T) {
+	A, h, err := Earth.Horizontal(2453097.0, 52, -5.0)
+	assert.NoError(t, err)
+	expected, _ := Earth.Azimuth(2453097.0, 52, -5.0)
+	assert.Equal(t, expected, A)
+	expected, _ = Earth.Altitude(2453097.0, 52, -5.0)
+	assert.Equal(t, expected, h)
+
+	_, _, err = Body(-1).Horizontal(2453097.0, 52, -5.0)
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+	_, _, err = Earth.Horizontal(2453097.0, 95, -5.0)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, _, err = Earth.With(VSOP87).Horizontal(2453097.0, 52, math.Inf(1))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+// Engine tests: the analytic engine follows SPA without atmosphere to within
+// its accuracy while the sun is up, over a year at 52°N.
+func TestEngines(t *testing.T) {
+	engines := []Engine{Earth, spa.SPA{}}
+
+	for jd := 2460311.0; jd < 2460311.0+366; jd += 0.37 {
+		var A, h [2]float64
+		for i, e := range engines {
+			var err error
+			A[i], h[i], err = e.Horizontal(jd, 52, -5.0)
+			assert.NoError(t, err)
+		}
+
+		if h[1] > 5 {
+			assert.InDelta(t, h[1], h[0], 0.3)
+			assert.Less(t, math.Abs(normalize180(A[1]-A[0])), 0.3)
+		}
+	}
+}
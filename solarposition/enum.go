@@ -0,0 +1,123 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+// The functions in this file take the planet enumeration (see README) as a
+// bare int and are kept so existing callers can migrate to Body gradually.
+
+// Mean anomaly (M), see Body.MeanAnomaly.
+//
+// Deprecated: use Body.MeanAnomaly.
+func MeanAnomaly(jd float64, p int) (float64, error) {
+	return Body(p).MeanAnomaly(jd)
+}
+
+// Obliquity ecliptic (e), see Body.ObliquityEcliptic.
+//
+// Deprecated: use Body.ObliquityEcliptic.
+func ObliquityEcliptic(p int) (float64, error) {
+	return Body(p).ObliquityEcliptic()
+}
+
+// Perihelion longitude (P), see Body.PerihelionLongitude.
+//
+// Deprecated: use Body.PerihelionLongitude.
+func PerihelionLongitude(p int) (float64, error) {
+	return Body(p).PerihelionLongitude()
+}
+
+// Equation of center (C), see Body.EquationOfCenter.
+//
+// Deprecated: use Body.EquationOfCenter.
+func EquationOfCenter(jd float64, p int) (float64, error) {
+	return Body(p).EquationOfCenter(jd)
+}
+
+// True anomaly (v), see Body.TrueAnomaly.
+//
+// Deprecated: use Body.TrueAnomaly.
+func TrueAnomaly(jd float64, p int) (float64, error) {
+	return Body(p).TrueAnomaly(jd)
+}
+
+// Ecliptic longitude (l), see Body.EclipticLongitude.
+//
+// Deprecated: use Body.EclipticLongitude.
+func EclipticLongitude(jd float64, p int) (float64, error) {
+	return Body(p).EclipticLongitude(jd)
+}
+
+// Right ascension (a), see Body.RightAscension.
+//
+// Deprecated: use Body.RightAscension.
+func RightAscension(jd float64, p int) (float64, error) {
+	return Body(p).RightAscension(jd)
+}
+
+// Declination (d), see Body.Declination.
+//
+// Deprecated: use Body.Declination.
+func Declination(jd float64, p int) (float64, error) {
+	return Body(p).Declination(jd)
+}
+
+// Sidereal time (theta), see Body.SiderealTime.
+//
+// Deprecated: use Body.SiderealTime.
+func SiderealTime(jd float64, p int, lon float64) (float64, error) {
+	return Body(p).SiderealTime(jd, lon)
+}
+
+// Hour angle (H), see Body.HourAngle.
+//
+// Deprecated: use Body.HourAngle.
+func HourAngle(jd float64, p int, lon float64) (float64, error) {
+	return Body(p).HourAngle(jd, lon)
+}
+
+// Azimuth (A), see Body.Azimuth.
+//
+// Deprecated: use Body.Azimuth.
+func Azimuth(jd float64, p int, lat, lon float64) (float64, error) {
+	return Body(p).Azimuth(jd, lat, lon)
+}
+
+// Altitude (h), see Body.Altitude.
+//
+// Deprecated: use Body.Altitude.
+func Altitude(jd float64, p int, lat, lon float64) (float64, error) {
+	return Body(p).Altitude(jd, lat, lon)
+}
+
+// Transit time (J_transit), see Body.TransitTime.
+//
+// Deprecated: use Body.TransitTime.
+func TransitTime(jd float64, p int, lon float64) (float64, error) {
+	return Body(p).TransitTime(jd, lon)
+}
+
+// Sunrise time (J_rise), see Body.SunriseTime.
+//
+// Deprecated: use Body.SunriseTime.
+func SunriseTime(jd float64, p int, lat, lon float64) (float64, error) {
+	return Body(p).SunriseTime(jd, lat, lon)
+}
+
+// Sunset time (J_set), see Body.SunsetTime.
+//
+// Deprecated: use Body.SunsetTime.
+func SunsetTime(jd float64, p int, lat, lon float64) (float64, error) {
+	return Body(p).SunsetTime(jd, lat, lon)
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/sidereal"
+)
+
+// Ephemeris provides the position of the sun as seen from a body. The hour
+// angle, the horizontal coordinates and the event times are calculated on top
+// of it by Sky; the analytic formulas of the package are the default.
+type Ephemeris interface {
+	// Equatorial returns the right ascension and the declination of the sun
+	// (in degrees), referred to the equator of the body.
+	//
+	// jd: julian day (TT).
+	Equatorial(b Body, jd float64) (float64, float64, error)
+}
+
+// EphemerisFunc adapts a function, for instance one interpolating a table of
+// positions, to the Ephemeris interface.
+type EphemerisFunc func(b Body, jd float64) (float64, float64, error)
+
+// Equatorial calls f(b, jd).
+func (f EphemerisFunc) Equatorial(b Body, jd float64) (float64, float64, error) {
+	return f(b, jd)
+}
+
+// Equatorial returns the equatorial coordinates of the sun as calculated by the
+// model, making every Model an Ephemeris.
+//
+// jd: julian day (TT).
+func (m Model) Equatorial(b Body, jd float64) (float64, float64, error) {
+	return b.EquatorialCoordinates(jd, m)
+}
+
+// Sky runs the calculations for a body on top of an ephemeris. The zero
+// Ephemeris is the analytic one, and the zero Solver refines event times with
+// the defaults.
+//
+// TimeScales gives ΔT, from which the ephemeris is read in dynamical time, and
+// the polar motion of the Earth. The zero value uses the built-in leap seconds
+// without Earth orientation parameters; iers.Data.TimeScales gives the ones
+// published by the IERS.
+type Sky struct {
+	Body       Body
+	Ephemeris  Ephemeris
+	Solver     Solver
+	TimeScales julian.TimeScales
+}
+
+// With returns the sky of the body on top of the ephemeris.
+func (b Body) With(e Ephemeris) Sky {
+	return Sky{Body: b, Ephemeris: e}
+}
+
+// Returns the ephemeris of the sky, defaulting to the analytic one.
+func (s Sky) ephemeris() Ephemeris {
+	if s.Ephemeris == nil {
+		return Analytic
+	}
+
+	return s.Ephemeris
+}
+
+// Transforms a julian day (UT1) into a julian day (TT) with the ΔT of the time
+// scales of the sky.
+func (s Sky) dynamicalTime(jd float64) float64 {
+	return dynamicalTime(s.TimeScales, jd)
+}
+
+// Returns the coordinates of the pole in arcseconds at a julian day (UT1), which
+// are only applied along with the apparent sidereal time of the Earth.
+func (s Sky) pole(jd float64) (float64, float64) {
+	if s.ephemeris() == Analytic || s.Body != Earth {
+		return 0, 0
+	}
+
+	return s.TimeScales.Pole(julian.FromJulianDay(jd, time.UTC))
+}
+
+// Returns the sidereal time the right ascensions of the ephemeris are measured
+// against. The analytic sidereal time of the Earth makes up for the
+// approximations of the analytic positions, so any other ephemeris is paired
+// with the apparent sidereal time of the IAU, at the longitude corrected for
+// polar motion. The other bodies have no better model of their rotation.
+//
+// jd: julian day (UT1).
+//
+// jde: the same julian day (TT).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) siderealTime(jd, jde float64, lat, lon float64) (float64, error) {
+	if s.ephemeris() == Analytic || s.Body != Earth {
+		return s.Body.SiderealTime(jd, lon)
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, err
+	}
+
+	x, y := s.pole(jd)
+	lon += sidereal.PolarMotionCorrection(lat, lon, x, y)
+
+	return sidereal.Local(sidereal.GAST(jd, jde), lon), nil
+}
+
+// HourAngle is Sky.HourAngle on the analytic ephemeris.
+func (b Body) HourAngle(jd float64, lon float64) (float64, error) {
+	return b.With(Analytic).HourAngle(jd, lon)
+}
+
+// Azimuth is Sky.Azimuth on the analytic ephemeris.
+func (b Body) Azimuth(jd float64, lat, lon float64) (float64, error) {
+	return b.With(Analytic).Azimuth(jd, lat, lon)
+}
+
+// Altitude is Sky.Altitude on the analytic ephemeris.
+func (b Body) Altitude(jd float64, lat, lon float64) (float64, error) {
+	return b.With(Analytic).Altitude(jd, lat, lon)
+}
+
+// Horizontal is Sky.Horizontal on the analytic ephemeris.
+func (b Body) Horizontal(jd float64, lat, lon float64) (float64, float64, error) {
+	return b.With(Analytic).Horizontal(jd, lat, lon)
+}
+
+// TransitTime is Sky.TransitTime on the analytic ephemeris.
+func (b Body) TransitTime(jd float64, lon float64) (float64, error) {
+	return b.With(Analytic).TransitTime(jd, lon)
+}
+
+// CrossingTime is Sky.CrossingTime on the analytic ephemeris.
+func (b Body) CrossingTime(
+	jd float64, h_0 float64, dir Direction, lat, lon float64,
+) (float64, error) {
+	return b.With(Analytic).CrossingTime(jd, h_0, dir, lat, lon)
+}
+
+// SunriseTime is Sky.SunriseTime on the analytic ephemeris.
+func (b Body) SunriseTime(jd float64, lat, lon float64) (float64, error) {
+	return b.With(Analytic).SunriseTime(jd, lat, lon)
+}
+
+// SunsetTime is Sky.SunsetTime on the analytic ephemeris.
+func (b Body) SunsetTime(jd float64, lat, lon float64) (float64, error) {
+	return b.With(Analytic).SunsetTime(jd, lat, lon)
+}
+
+// DawnTime is Sky.DawnTime on the analytic ephemeris.
+func (b Body) DawnTime(jd float64, t Twilight, lat, lon float64) (float64, error) {
+	return b.With(Analytic).DawnTime(jd, t, lat, lon)
+}
+
+// DuskTime is Sky.DuskTime on the analytic ephemeris.
+func (b Body) DuskTime(jd float64, t Twilight, lat, lon float64) (float64, error) {
+	return b.With(Analytic).DuskTime(jd, t, lat, lon)
+}
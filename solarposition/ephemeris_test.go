@@ -0,0 +1,138 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/sidereal"
+	"github.com/codymj/celestia/spa"
+	"github.com/stretchr/testify/assert"
+)
+
+// Sky tests: the zero ephemeris is the analytic one the Body methods use.
+func TestSkyDefault(t *testing.T) {
+	for _, b := range Bodies() {
+		s := Sky{Body: b}
+
+		expected, _ := b.Altitude(2453097.0, 52, -5.0)
+		actual, err := s.Altitude(2453097.0, 52, -5.0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		expected, _ = b.TransitTime(2453097.0, -5.0)
+		actual, err = s.TransitTime(2453097.0, -5.0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+// EphemerisFunc tests: a plugged in ephemeris drives the higher layers.
+func TestEphemerisFunc(t *testing.T) {
+	// A sun fixed on the equator at right ascension 0.
+	fixed := EphemerisFunc(func(b Body, jd float64) (float64, float64, error) {
+		return 0, 0, nil
+	})
+	s := Mars.With(fixed)
+
+	theta, _ := Mars.SiderealTime(2453097.0, -5.0)
+	H, err := s.HourAngle(2453097.0, -5.0)
+	assert.NoError(t, err)
+	assert.Equal(t, theta, H)
+
+	// On the equator such a sun rises and sets a quarter of a sidereal day
+	// from the transit, whatever the latitude.
+	J, err := s.TransitTime(2453097.0, -5.0)
+	assert.NoError(t, err)
+	rise, err := s.CrossingTime(2453097.0, 0, Rising, 45, -5.0)
+	assert.NoError(t, err)
+	set, err := s.CrossingTime(2453097.0, 0, Setting, 45, -5.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 90/T1Mars, J-rise, 1e-5)
+	assert.InDelta(t, 90/T1Mars, set-J, 1e-5)
+
+	// Errors of the ephemeris are passed on.
+	failing := EphemerisFunc(func(b Body, jd float64) (float64, float64, error) {
+		return 0, 0, ErrUnsupportedModel
+	})
+	_, err = Earth.With(failing).SunriseTime(2453097.0, 52, -5.0)
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+}
+
+// VSOP87 ephemeris tests: the geocentric position follows SPA to within the
+// parallax of the sun, over a year at 52°N.
+func TestSkyVSOP87(t *testing.T) {
+	s := Earth.With(VSOP87)
+
+	for jd := 2460311.0; jd < 2460311.0+366; jd += 0.37 {
+		A, h, err := s.Horizontal(jd, 52, -5.0)
+		assert.NoError(t, err)
+
+		r := spa.SPA{}.Calculate(jd, 52, -5.0)
+		assert.InDelta(t, r.E, h, 0.003)
+		if r.E > -80 {
+			assert.InDelta(t, r.AzimuthAstro, A, 0.003)
+		}
+	}
+
+	// The events follow the analytic ones to within a minute.
+	expected, _ := Earth.SunriseTime(2460311.0, 52, -5.0)
+	actual, err := s.SunriseTime(2460311.0, 52, -5.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected, actual, 1.0/1440)
+
+	_, err = Mars.With(VSOP87).SunriseTime(2460311.0, 52, -5.0)
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+}
+
+// Sky time scales tests: ΔT follows UT1 - UTC, and the apparent sidereal time
+// of the Earth follows the polar motion.
+func TestSkyTimeScales(t *testing.T) {
+	jd, lat, lon := 2460400.75, 60.0, -5.0
+	ts := julian.TimeScales{
+		UT1MinusUTC: func(time.Time) (float64, bool) { return 0.5, true },
+		PolarMotion: func(time.Time) (float64, float64, bool) { return 0.3, 0.4, true },
+	}
+	base := Earth.With(VSOP87)
+	sky := base
+	sky.TimeScales = ts
+
+	dt := julian.DeltaT(julian.FromJulianDay(jd, time.UTC))
+	assert.InDelta(t, jd+(dt-0.5)/julian.SecondsPerDay, sky.dynamicalTime(jd), 1e-9)
+
+	// Moving the pole is moving the observer.
+	sky.TimeScales = julian.TimeScales{PolarMotion: ts.PolarMotion}
+	dl := sidereal.PolarMotionCorrection(lat, lon, 0.3, 0.4)
+	assert.NotZero(t, dl)
+
+	expected, _ := base.Position(jd, Observer{Latitude: lat, Longitude: lon + dl})
+	actual, err := sky.Position(jd, Observer{Latitude: lat, Longitude: lon})
+	assert.NoError(t, err)
+	assert.InDelta(t, expected.SiderealTime, actual.SiderealTime, 1e-9)
+	assert.InDelta(t, expected.Azimuth, actual.Azimuth, 1e-9)
+
+	A, h, err := sky.Horizontal(jd, lat, lon)
+	assert.NoError(t, err)
+	assert.InDelta(t, actual.Azimuth, A, 1e-9)
+	assert.InDelta(t, actual.Altitude, h, 1e-9)
+
+	// The analytic sidereal time is not accurate enough for polar motion.
+	analytic := Sky{Body: Earth, TimeScales: sky.TimeScales}
+	expected, _ = Earth.Position(jd, Observer{Latitude: lat, Longitude: lon})
+	actual, _ = analytic.Position(jd, Observer{Latitude: lat, Longitude: lon})
+	assert.Equal(t, expected, actual)
+}
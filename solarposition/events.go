@@ -0,0 +1,263 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// EventKind is the kind of an event in the course of the sun across the sky.
+type EventKind int
+
+const (
+	// Rise is the top of the solar disk coming up over the horizon.
+	Rise EventKind = iota
+
+	// Set is the top of the solar disk going down below the horizon.
+	Set
+
+	// Transit is the sun passing the meridian above the pole.
+	Transit
+
+	// Dawn is the center of the solar disk coming up over the altitude of a
+	// twilight.
+	Dawn
+
+	// Dusk is the center of the solar disk going down below the altitude of a
+	// twilight.
+	Dusk
+)
+
+// String returns the name of the kind of event.
+func (k EventKind) String() string {
+	switch k {
+	case Rise:
+		return "rise"
+	case Set:
+		return "set"
+	case Transit:
+		return "transit"
+	case Dawn:
+		return "dawn"
+	case Dusk:
+		return "dusk"
+	default:
+		return "invalid"
+	}
+}
+
+// Event is a rise, set or transit of the sun, or the beginning or end of a
+// twilight.
+type Event struct {
+	Kind EventKind
+
+	// Julian day (UT1) of the event.
+	JD float64
+
+	// Twilight that begins at a Dawn or ends at a Dusk, and NoTwilight for
+	// the other kinds of event.
+	Twilight Twilight
+}
+
+// Number of samples per solar day taken by the event search. Crossings closer
+// together than a sample, a quarter of a degree of hour angle, can be missed.
+const eventSamples = 1440
+
+// Altitude which the sun crosses upwards at one kind of event and downwards at
+// another.
+type threshold struct {
+	up, down EventKind
+	twilight Twilight
+	h        func(A float64) float64
+}
+
+// Events returns every rise, set and transit of the sun seen by the observer
+// between two julian days, in order. Unlike the event times around a transit,
+// it makes no assumption on their sequence: when the sun briefly moves
+// backwards across the sky of Mercury around perihelion it can rise, set and
+// rise again, or pass the meridian three times. The dawn and dusk of each of
+// the given twilights are returned as well.
+//
+// start: julian day (UT1) to search from.
+//
+// end: julian day (UT1) to search to.
+//
+// o: observer.
+//
+// twilights: kinds of twilight to search for.
+func (s Sky) Events(start, end float64, o Observer, twilights ...Twilight) ([]Event, error) {
+	var events []Event
+	err := s.searchEvents(start, end, o, twilights, func(e Event) bool {
+		events = append(events, e)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// EventsSeq is Events as an iterator, which yields the events as they are
+// found without holding them in memory. A search error is yielded once with a
+// zero Event, after which the iteration stops.
+func (s Sky) EventsSeq(start, end float64, o Observer, twilights ...Twilight) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		err := s.searchEvents(start, end, o, twilights, func(e Event) bool {
+			return yield(e, nil)
+		})
+		if err != nil {
+			yield(Event{}, err)
+		}
+	}
+}
+
+// Searches the events between two julian days and passes them to yield in
+// order, until yield returns false.
+func (s Sky) searchEvents(start, end float64, o Observer, twilights []Twilight, yield func(Event) bool) error {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return err
+	}
+
+	h_0, err := s.horizon(o)
+	if err != nil {
+		return err
+	}
+
+	thresholds := []threshold{{Rise, Set, NoTwilight, h_0}}
+	for _, t := range twilights {
+		h, err := t.Altitude()
+		if err != nil {
+			return err
+		}
+
+		thresholds = append(thresholds, threshold{
+			up:       Dawn,
+			down:     Dusk,
+			twilight: t,
+			h:        func(float64) float64 { return h },
+		})
+	}
+
+	// The sun is sampled once per step; every threshold is compared against
+	// the same altitude and azimuth.
+	lat, lon := o.Latitude, o.Longitude
+	sample := func(J float64, g []float64) (float64, error) {
+		H, d, err := s.hourAngle(J, lat, lon)
+		if err != nil {
+			return 0, err
+		}
+		H = normalize180(H)
+
+		h, A := altitude(H, d, lat), azimuth(H, d, lat)
+		for i, c := range thresholds {
+			g[i] = h - c.h(A)
+		}
+
+		return H, nil
+	}
+	above := func(c threshold) func(float64) (float64, error) {
+		return func(J float64) (float64, error) {
+			H, d, err := s.hourAngle(J, lat, lon)
+			if err != nil {
+				return 0, err
+			}
+
+			return altitude(H, d, lat) - c.h(azimuth(H, d, lat)), nil
+		}
+	}
+	hourAngle := func(J float64) (float64, error) {
+		H, _, err := s.hourAngle(J, lat, lon)
+		return normalize180(H), err
+	}
+
+	var found []Event
+	find := func(e Event, f func(float64) (float64, error), a, b float64) error {
+		r, err := s.Solver.Brent(f, a, b)
+		if err != nil {
+			return err
+		}
+
+		e.JD = r.JD
+		found = append(found, e)
+		return nil
+	}
+
+	step := math.Abs(bp.solarDay()) / eventSamples
+	g0 := make([]float64, len(thresholds))
+	g1 := make([]float64, len(thresholds))
+	J0 := start
+	H0, err := sample(J0, g0)
+	if err != nil {
+		return err
+	}
+
+	for J0 < end {
+		J1 := math.Min(J0+step, end)
+		H1, err := sample(J1, g1)
+		if err != nil {
+			return err
+		}
+
+		found = found[:0]
+		for i, c := range thresholds {
+			switch {
+			case g0[i] < 0 && g1[i] >= 0:
+				err = find(Event{Kind: c.up, Twilight: c.twilight}, above(c), J0, J1)
+			case g0[i] >= 0 && g1[i] < 0:
+				err = find(Event{Kind: c.down, Twilight: c.twilight}, above(c), J0, J1)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		// The hour angle also changes sign opposite the meridian, where it
+		// wraps around from 180° to -180°.
+		if (H0 < 0) != (H1 < 0) && math.Abs(H0) < 90 && math.Abs(H1) < 90 {
+			if err := find(Event{Kind: Transit, Twilight: NoTwilight}, hourAngle, J0, J1); err != nil {
+				return err
+			}
+		}
+
+		// A rise and a transit can fall within the same step.
+		sort.SliceStable(found, func(i, j int) bool {
+			return found[i].JD < found[j].JD
+		})
+		for _, e := range found {
+			if !yield(e) {
+				return nil
+			}
+		}
+
+		J0, H0 = J1, H1
+		g0, g1 = g1, g0
+	}
+
+	return nil
+}
+
+// Events is Sky.Events on the analytic ephemeris.
+func (b Body) Events(start, end float64, o Observer, twilights ...Twilight) ([]Event, error) {
+	return b.With(Analytic).Events(start, end, o, twilights...)
+}
+
+// EventsSeq is Sky.EventsSeq on the analytic ephemeris.
+func (b Body) EventsSeq(start, end float64, o Observer, twilights ...Twilight) iter.Seq2[Event, error] {
+	return b.With(Analytic).EventsSeq(start, end, o, twilights...)
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the kinds of the events.
+func kinds(events []Event) []EventKind {
+	ks := make([]EventKind, len(events))
+	for i, e := range events {
+		ks[i] = e.Kind
+	}
+
+	return ks
+}
+
+// Events tests: on the Earth the search finds the same events as the event
+// times around each transit.
+func TestEvents(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	events, err := Earth.Events(2453097.0, 2453099.0, o)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventKind{Set, Rise, Transit, Set, Rise, Transit}, kinds(events))
+
+	for _, e := range events {
+		var expected float64
+		switch e.Kind {
+		case Rise:
+			expected, _ = Earth.SunriseTimeAt(e.JD, o)
+		case Set:
+			expected, _ = Earth.SunsetTimeAt(e.JD, o)
+		case Transit:
+			expected, _ = Earth.TransitTime(e.JD, o.Longitude)
+		}
+		assert.InDelta(t, expected, e.JD, 0.05/86400, "%v", e.Kind)
+	}
+}
+
+// Events tests: the sun of Mercury rises, sets and rises again at longitudes
+// 90° from those where it passes the meridian three times.
+func TestEventsMercury(t *testing.T) {
+	start, end := 2451545.0, 2451545.0+176
+
+	events, err := Mercury.Events(start, end, NewObserver(0, 90))
+	assert.NoError(t, err)
+	assert.Equal(t, []EventKind{Rise, Set, Rise, Transit, Set}, kinds(events))
+	assert.InDelta(t, 2451582.0268, events[0].JD, 1e-4)
+	assert.InDelta(t, 2451593.1387, events[1].JD, 1e-4)
+	assert.InDelta(t, 2451595.4007, events[2].JD, 1e-4)
+
+	events, err = Mercury.Events(start, end, NewObserver(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []EventKind{Rise, Transit, Transit, Transit, Set}, kinds(events))
+}
+
+// Events tests.
+func TestEventsOther(t *testing.T) {
+	tests := []struct {
+		name  string
+		b     Body
+		start float64
+		end   float64
+		lat   float64
+		kinds []EventKind
+		err   error
+	}{
+		{"Venus", Venus, 2451545.0, 2451545.0 + 120, 0, []EventKind{Rise, Transit, Set}, nil},
+		{"PolarNight", Earth, 2453358.0, 2453361.0, 80, []EventKind{Transit, Transit, Transit}, nil},
+		{"MidnightSun", Earth, 2453176.0, 2453178.0, 80, []EventKind{Transit, Transit}, nil},
+		{"Empty", Earth, 2453097.0, 2453097.0, 52, nil, nil},
+		{"InvalidPlanet", Body(23), 2453097.0, 2453098.0, 52, nil, ErrInvalidEnum},
+		{"InvalidLatitude", Earth, 2453097.0, 2453098.0, 91, nil, ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := tt.b.Events(tt.start, tt.end, NewObserver(tt.lat, 0))
+			assert.ErrorIs(t, err, tt.err)
+			if tt.kinds == nil {
+				assert.Empty(t, events)
+				return
+			}
+			assert.Equal(t, tt.kinds, kinds(events))
+		})
+	}
+
+	expected, _ := Venus.SunriseTimeAt(2451567.5, NewObserver(0, 0))
+	events, _ := Venus.Events(2451545.0, 2451545.0+120, NewObserver(0, 0))
+	assert.InDelta(t, expected, events[0].JD, 0.05/86400)
+
+	assert.Equal(t, "transit", Transit.String())
+	assert.Equal(t, "dusk", Dusk.String())
+	assert.Equal(t, "invalid", EventKind(7).String())
+}
+
+// Events tests: the dawn and dusk of each twilight are found between the
+// sunrise and sunset events, at the times of DawnTime and DuskTime.
+func TestEventsTwilight(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	events, err := Earth.Events(2453097.0, 2453098.0, o, Civil, Astronomical)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventKind{Set, Dusk, Dusk, Dawn, Dawn, Rise, Transit}, kinds(events))
+	twilights := make([]Twilight, len(events))
+	for i, e := range events {
+		twilights[i] = e.Twilight
+	}
+	assert.Equal(t, []Twilight{
+		NoTwilight, Civil, Astronomical, Astronomical, Civil, NoTwilight, NoTwilight,
+	}, twilights)
+	assert.Equal(t, "none", NoTwilight.String())
+
+	for _, e := range events[1:5] {
+		var expected float64
+		switch e.Kind {
+		case Dawn:
+			expected, _ = Earth.DawnTime(e.JD, e.Twilight, o.Latitude, o.Longitude)
+		case Dusk:
+			expected, _ = Earth.DuskTime(e.JD, e.Twilight, o.Latitude, o.Longitude)
+		}
+		assert.InDelta(t, expected, e.JD, 0.05/86400, "%v %v", e.Kind, e.Twilight)
+	}
+
+	_, err = Earth.Events(2453097.0, 2453098.0, o, Twilight(7))
+	assert.ErrorIs(t, err, ErrInvalidTwilight)
+	_, err = Earth.Events(2453097.0, 2453098.0, o, NoTwilight)
+	assert.ErrorIs(t, err, ErrInvalidTwilight)
+}
+
+// Events tests: the iterator yields the same events as Events, and stops when
+// the loop breaks or at the first error.
+func TestEventsSeq(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	expected, _ := Earth.Events(2453097.0, 2453107.0, o, Nautical)
+
+	var events []Event
+	for e, err := range Earth.EventsSeq(2453097.0, 2453107.0, o, Nautical) {
+		assert.NoError(t, err)
+		events = append(events, e)
+	}
+	assert.Equal(t, expected, events)
+
+	n := 0
+	for range Earth.EventsSeq(2453097.0, 2453107.0, o) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, n)
+
+	n = 0
+	for e, err := range Body(23).EventsSeq(2453097.0, 2453107.0, o) {
+		assert.ErrorIs(t, err, ErrInvalidEnum)
+		assert.Equal(t, Event{}, e)
+		n++
+	}
+	assert.Equal(t, 1, n)
+}
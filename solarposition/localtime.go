@@ -0,0 +1,109 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"errors"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+var (
+	ErrNoEvent = errors.New("event does not occur on this date")
+)
+
+// Finds the event that falls on the civil date in loc. The event function
+// returns the event nearest to the julian day it is given, so the event around
+// the middle of the local day is tried first and then those of the days either
+// side, since large offsets from local mean time can push an event onto the
+// neighbouring date.
+func eventOnDate(
+	date time.Time, loc *time.Location, event func(jd float64) (float64, error),
+) (time.Time, error) {
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+
+	// Days are not always 24 hours long when daylight saving time changes.
+	jd := julian.ToJulianDay(start.Add(end.Sub(start) / 2).UTC())
+
+	for i, offset := range []float64{0, -1, 1} {
+		J, err := event(jd + offset)
+		if err != nil {
+			if i == 0 {
+				return time.Time{}, err
+			}
+			continue
+		}
+
+		t := julian.FromJulianDay(J, loc)
+		if !t.Before(start) && t.Before(end) {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, ErrNoEvent
+}
+
+// Sunrise is the time at which the sun rises on the civil date in the time
+// zone of loc on the Earth. Only the year, month and day of date are used.
+// ErrPolarNight or ErrMidnightSun is returned when the sun does not rise, and
+// ErrNoEvent when the sunrise falls on a neighbouring date.
+//
+// date: civil date.
+//
+// loc: time zone of the observer.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func Sunrise(date time.Time, loc *time.Location, lat, lon float64) (time.Time, error) {
+	return eventOnDate(date, loc, func(jd float64) (float64, error) {
+		return Earth.SunriseTime(jd, lat, lon)
+	})
+}
+
+// Solar noon is the time at which the sun transits on the civil date in the
+// time zone of loc on the Earth. Only the year, month and day of date are used.
+//
+// date: civil date.
+//
+// loc: time zone of the observer.
+//
+// lon: longitude (west).
+func SolarNoon(date time.Time, loc *time.Location, lon float64) (time.Time, error) {
+	return eventOnDate(date, loc, func(jd float64) (float64, error) {
+		return Earth.TransitTime(jd, lon)
+	})
+}
+
+// Sunset is the time at which the sun sets on the civil date in the time zone
+// of loc on the Earth. Only the year, month and day of date are used.
+// ErrPolarNight or ErrMidnightSun is returned when the sun does not set, and
+// ErrNoEvent when the sunset falls on a neighbouring date.
+//
+// date: civil date.
+//
+// loc: time zone of the observer.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func Sunset(date time.Time, loc *time.Location, lat, lon float64) (time.Time, error) {
+	return eventOnDate(date, loc, func(jd float64) (float64, error) {
+		return Earth.SunsetTime(jd, lat, lon)
+	})
+}
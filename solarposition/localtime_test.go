@@ -0,0 +1,97 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Local event tests, covering daylight saving time changes and time zones far
+// from local mean time.
+func TestLocalEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		loc     string
+		lat     float64
+		lon     float64
+		sunrise string
+		noon    string
+		sunset  string
+		err     error
+	}{
+		{
+			"SpringForward", "2024-03-10", "America/New_York", 40.7128, 74.0060,
+			"2024-03-10T07:16:03-04:00",
+			"2024-03-10T13:06:41-04:00",
+			"2024-03-10T18:57:58-04:00",
+			nil,
+		},
+		{
+			"FallBack", "2024-11-03", "America/New_York", 40.7128, 74.0060,
+			"2024-11-03T06:29:20-05:00",
+			"2024-11-03T11:40:02-05:00",
+			"2024-11-03T16:50:14-05:00",
+			nil,
+		},
+		{
+			"DateLine", "2024-06-01", "Pacific/Kiritimati", 1.87, 157.4,
+			"2024-06-01T06:21:08+14:00",
+			"2024-06-01T12:27:47+14:00",
+			"2024-06-01T18:34:26+14:00",
+			nil,
+		},
+		{
+			"MidnightSun", "2024-06-21", "Arctic/Longyearbyen", 78.2, -15.6,
+			"",
+			"2024-06-21T12:59:49+02:00",
+			"",
+			ErrMidnightSun,
+		},
+	}
+
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(time.RFC3339)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.loc)
+			assert.NoError(t, err)
+			date, err := time.Parse(time.DateOnly, tt.date)
+			assert.NoError(t, err)
+
+			sunrise, err := Sunrise(date, loc, tt.lat, tt.lon)
+			assert.Equal(t, tt.sunrise, format(sunrise))
+			assert.Equal(t, tt.err, err)
+
+			noon, err := SolarNoon(date, loc, tt.lon)
+			assert.Equal(t, tt.noon, format(noon))
+			assert.NoError(t, err)
+
+			sunset, err := Sunset(date, loc, tt.lat, tt.lon)
+			assert.Equal(t, tt.sunset, format(sunset))
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/nutation"
+	"github.com/codymj/celestia/vsop87"
+)
+
+// Model is the theory the position of the sun is calculated with.
+type Model int
+
+const (
+	// Analytic is the approximation of Astronomy Answers used by the rest of
+	// the package: fast, and good to about a tenth of a degree in longitude.
+	Analytic Model = iota
+
+	// VSOP87 derives the apparent position of the sun from the VSOP87D theory,
+	// corrected for nutation and aberration, to about an arcsecond. It is only
+	// available for the Earth.
+	VSOP87
+)
+
+// Constant of aberration (in degrees).
+const aberration = 20.4898 / 3600
+
+// String returns the name of the model.
+func (m Model) String() string {
+	switch m {
+	case Analytic:
+		return "analytic"
+	case VSOP87:
+		return "VSOP87"
+	default:
+		return "invalid"
+	}
+}
+
+// Ecliptic coordinates (l, beta) are the longitude and latitude of the sun
+// relative to the ecliptic (in degrees), as calculated by the model. The
+// analytic model places the sun on the ecliptic.
+//
+// jd: julian day (TT).
+//
+// m: model.
+func (b Body) EclipticCoordinates(jd float64, m Model) (float64, float64, error) {
+	switch {
+	case m == Analytic:
+		l, err := b.EclipticLongitude(jd)
+		return l, 0, err
+	case m == VSOP87 && b == Earth:
+		l, beta := apparentEarth(vsop87.Earth, jd)
+
+		return l, beta, nil
+	default:
+		return 0, 0, ErrUnsupportedModel
+	}
+}
+
+// Equatorial coordinates (a, d) are the right ascension and the declination of
+// the sun (in degrees), as calculated by the model.
+//
+// jd: julian day (TT).
+//
+// m: model.
+func (b Body) EquatorialCoordinates(jd float64, m Model) (float64, float64, error) {
+	if m == Analytic {
+		bp, err := b.parameters()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		M := bp.meanAnomaly(jd)
+		a, d := equatorial(bp.eclipticLongitude(M, bp.equationOfCenter(M)), 0, bp.E)
+
+		return a, d, nil
+	}
+
+	l, beta, err := b.EclipticCoordinates(jd, m)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The models other than the analytic one are Earth only.
+	a, d := equatorial(l, beta, nutation.TrueObliquity(jd))
+
+	return a, d, nil
+}
+
+// Returns the apparent ecliptic longitude and latitude of the sun seen from the
+// Earth on a theory of the Earth, corrected for nutation and aberration.
+//
+// jd: julian day (TT).
+func apparentEarth(th *vsop87.Theory, jd float64) (float64, float64) {
+	L, B, R := th.Position(jd)
+	dpsi, _ := nutation.Nutation(jd)
+
+	// The sun is seen from the opposite side of the Earth's orbit.
+	l := math.Mod(L+180+dpsi-aberration/R, 360.0)
+
+	return l, -B
+}
+
+// Theories is an Ephemeris on VSOP87D theories, one per body, such as the
+// complete series read with vsop87.Read. From the Earth the sun is placed as by
+// the VSOP87 model. From the other planets it is seen opposite the
+// heliocentric position of the planet, corrected for aberration, and referred
+// to the equator of the planet, whose pole is given by Pole, and to its
+// equinox, where the sun crosses that equator going north. ErrUnsupportedModel
+// is returned for the bodies without a theory.
+type Theories map[Body]*vsop87.Theory
+
+// Speed of light (in AU per day).
+const speedOfLight = 173.1446326846693
+
+// General precession in longitude (in degrees per julian century), which
+// carries the equinox of J2000 to that of the date.
+const generalPrecession = 5028.796195 / 3600
+
+// Equatorial calculates the apparent right ascension and declination of the
+// sun seen from a body (in degrees) on its theory.
+//
+// jd: julian day (TT).
+func (t Theories) Equatorial(b Body, jd float64) (float64, float64, error) {
+	pa, pd, err := b.Pole()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	th := t[b]
+	if th == nil {
+		return 0, 0, ErrUnsupportedModel
+	}
+
+	if b == Earth {
+		l, beta := apparentEarth(th, jd)
+		a, d := equatorial(l, beta, nutation.TrueObliquity(jd))
+
+		return a, d, nil
+	}
+
+	// Heliocentric position and velocity of the planet (in AU and AU per
+	// day) on the ecliptic and equinox of the date.
+	const step = 0.01
+	r := rectangular(th.Position(jd))
+	r0, r1 := rectangular(th.Position(jd-step)), rectangular(th.Position(jd+step))
+	var v [3]float64
+	for i := range v {
+		v[i] = (r1[i] - r0[i]) / (2 * step)
+	}
+
+	// The sun lies opposite the planet, shifted towards the motion of the
+	// planet by aberration, to first order.
+	n := math.Sqrt(dot(r, r))
+	var p [3]float64
+	for i := range p {
+		p[i] = -r[i]/n + v[i]/speedOfLight
+	}
+	p = unit(p)
+
+	// The pole is carried from the equator of J2000 to the ecliptic and
+	// equinox of the date, leaving out the slow motion of the ecliptic.
+	pole := rectangular(pa, pd, 1)
+	e := nutation.MeanObliquity(julian.J2000) * RAD
+	pole = [3]float64{
+		pole[0],
+		pole[1]*math.Cos(e) + pole[2]*math.Sin(e),
+		-pole[1]*math.Sin(e) + pole[2]*math.Cos(e),
+	}
+	lon, lat, _ := spherical(pole)
+	T := (jd - julian.J2000) / 36525
+	pole = rectangular(lon+generalPrecession*T, lat, 1)
+
+	// The orbit of the planet gives the plane the sun moves in, whose
+	// intersection with the equator is the line of the equinoxes.
+	equinox := unit(cross(pole, cross(r, v)))
+
+	a := math.Atan2(dot(p, cross(pole, equinox)), dot(p, equinox)) * DEG
+	if a < 0 {
+		a += 360
+	}
+	d := math.Asin(dot(p, pole)) * DEG
+
+	return a, d, nil
+}
+
+// Returns the rectangular coordinates of a point at longitude l and latitude b
+// (in degrees) and distance r.
+func rectangular(l, b, r float64) [3]float64 {
+	return [3]float64{
+		r * math.Cos(b*RAD) * math.Cos(l*RAD),
+		r * math.Cos(b*RAD) * math.Sin(l*RAD),
+		r * math.Sin(b*RAD),
+	}
+}
+
+// Returns the longitude, between 0° and 360°, latitude and length of a vector.
+func spherical(v [3]float64) (float64, float64, float64) {
+	r := math.Sqrt(dot(v, v))
+	l := math.Atan2(v[1], v[0]) * DEG
+	if l < 0 {
+		l += 360
+	}
+
+	return l, math.Asin(v[2]/r) * DEG, r
+}
+
+// Returns the cross product of two vectors.
+func cross(u, v [3]float64) [3]float64 {
+	return [3]float64{
+		u[1]*v[2] - u[2]*v[1],
+		u[2]*v[0] - u[0]*v[2],
+		u[0]*v[1] - u[1]*v[0],
+	}
+}
+
+// Returns the dot product of two vectors.
+func dot(u, v [3]float64) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
+
+// Returns the vector scaled to unit length.
+func unit(v [3]float64) [3]float64 {
+	r := math.Sqrt(dot(v, v))
+
+	return [3]float64{v[0] / r, v[1] / r, v[2] / r}
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/codymj/celestia/vsop87"
+	"github.com/stretchr/testify/assert"
+)
+
+// EclipticCoordinates and EquatorialCoordinates tests, against the NREL SPA
+// example (2003-10-17 19:30:30 UT, ΔT 67 s).
+func TestModelCoordinates(t *testing.T) {
+	jd := 2452930.312847222 + 67.0/86400
+
+	l, beta, err := Earth.EclipticCoordinates(jd, VSOP87)
+	assert.NoError(t, err)
+	assert.InDelta(t, 204.0085519281, l, 1e-8)
+	assert.InDelta(t, 0.0001011219, beta, 1e-8)
+
+	a, d, err := Earth.EquatorialCoordinates(jd, VSOP87)
+	assert.NoError(t, err)
+	assert.InDelta(t, 202.22741-360, a, 1e-5)
+	assert.InDelta(t, -9.31434, d, 1e-5)
+}
+
+// Analytic model tests, which must match the functions of the package.
+func TestModelAnalytic(t *testing.T) {
+	jd := 2452930.3136
+
+	for _, b := range Bodies() {
+		l, beta, err := b.EclipticCoordinates(jd, Analytic)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, beta)
+		expected, _ := b.EclipticLongitude(jd)
+		assert.Equal(t, expected, l)
+
+		a, d, err := b.EquatorialCoordinates(jd, Analytic)
+		assert.NoError(t, err)
+		expected, _ = b.RightAscension(jd)
+		assert.Equal(t, expected, a)
+		expected, _ = b.Declination(jd)
+		assert.Equal(t, expected, d)
+	}
+}
+
+// Unsupported model tests.
+func TestModelUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		body Body
+		m    Model
+	}{
+		{"Mars", Mars, VSOP87},
+		{"Invalid", Earth, Model(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.body.EclipticCoordinates(2451545.0, tt.m)
+			assert.ErrorIs(t, err, ErrUnsupportedModel)
+			_, _, err = tt.body.EquatorialCoordinates(2451545.0, tt.m)
+			assert.ErrorIs(t, err, ErrUnsupportedModel)
+		})
+	}
+
+	_, _, err := Body(-1).EclipticCoordinates(2451545.0, Analytic)
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
+
+// Theories tests: the sample holds the leading terms of the VSOP87D.mar file
+// distributed by the IMCCE. The sun seen from Mars follows the analytic model
+// and crosses the equator of Mars northward at the equinoxes of 2000 May 31
+// and 2024 November 12.
+func TestTheories(t *testing.T) {
+	f, err := os.Open("testdata/VSOP87D.mar.sample")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	mars, err := vsop87.Read(f)
+	assert.NoError(t, err)
+	theories := Theories{Earth: vsop87.Earth, Mars: mars}
+
+	for jd := 2451545.0; jd < 2451545.0+3650; jd += 7.3 {
+		a, d, err := theories.Equatorial(Mars, jd)
+		assert.NoError(t, err)
+
+		ea, ed, _ := Mars.EquatorialCoordinates(jd, Analytic)
+		assert.InDelta(t, 0, math.Remainder(a-ea, 360), 0.25)
+		assert.InDelta(t, ed, d, 0.05)
+	}
+
+	for _, jd := range []float64{2451696.5, 2460627.0} {
+		_, before, _ := theories.Equatorial(Mars, jd-1)
+		_, after, _ := theories.Equatorial(Mars, jd+1)
+		assert.Less(t, before, 0.0)
+		assert.Greater(t, after, 0.0)
+	}
+
+	// The theory of the Earth places the sun as the VSOP87 model does.
+	a, d, err := theories.Equatorial(Earth, 2452930.3)
+	assert.NoError(t, err)
+	ea, ed, _ := Earth.EquatorialCoordinates(2452930.3, VSOP87)
+	assert.Equal(t, ea, a)
+	assert.Equal(t, ed, d)
+
+	// The theories drive the higher layers.
+	_, err = Mars.With(theories).SunriseTime(2460400.5, 22, 40)
+	assert.NoError(t, err)
+
+	_, _, err = theories.Equatorial(Venus, 2451545.0)
+	assert.ErrorIs(t, err, ErrUnsupportedModel)
+	_, _, err = theories.Equatorial(Body(-1), 2451545.0)
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
@@ -0,0 +1,225 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import "math"
+
+// Observer is a place on the surface of a body from which the sun is observed,
+// together with the air above it. Only the atmosphere of the earth is
+// modelled; on the other bodies the refraction is the one included in the
+// sunrise altitude (h_0) of the body.
+type Observer struct {
+	// Latitude (north) and longitude (west) in degrees.
+	Latitude, Longitude float64
+
+	// Elevation (in m) above the visible horizon, such as sea level for an
+	// observer looking out at sea. It lowers the horizon by the dip.
+	Elevation float64
+
+	// Pressure (in mbar) and temperature (in °C) of the air at the observer,
+	// which scale the refraction. A zero pressure means there is no air.
+	Pressure, Temperature float64
+
+	// Refraction is the model of the refraction, Bennett's by default.
+	Refraction Refraction
+
+	// Horizon returns the apparent altitude (in degrees) of the visible
+	// horizon at azimuth A, for an observer surrounded by terrain. A nil
+	// Horizon is a flat horizon, lowered by the dip.
+	Horizon func(A float64) float64
+}
+
+const (
+	// Atmosphere for which the refraction formulas are given.
+	standardPressure    = 1010.0
+	standardTemperature = 10.0
+
+	// Coefficient of terrestrial refraction, which bends the line of sight to
+	// the horizon along the curvature of the earth and so reduces the dip.
+	terrestrialRefraction = 0.13
+)
+
+// NewObserver returns an observer at sea level in the standard atmosphere.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func NewObserver(lat, lon float64) Observer {
+	return Observer{
+		Latitude:    lat,
+		Longitude:   lon,
+		Pressure:    standardPressure,
+		Temperature: standardTemperature,
+	}
+}
+
+// Returns the dip (in degrees), the angle by which the visible horizon lies
+// below the astronomical horizon for an observer above it.
+func (o Observer) dip(b Body, bp *parameters) float64 {
+	if o.Elevation <= 0 {
+		return 0
+	}
+
+	R := bp.R * 1000
+	if b == Earth && o.Refraction != NoRefraction {
+		R /= 1 - terrestrialRefraction*atmosphere(o.Pressure, o.Temperature)
+	}
+
+	return math.Acos(R/(R+o.Elevation)) * DEG
+}
+
+// Azimuth (A) of the sun as seen by the observer.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) AzimuthAt(jd float64, o Observer) (float64, error) {
+	if err := o.Validate(); err != nil {
+		return 0, err
+	}
+
+	return s.Azimuth(jd, o.Latitude, o.Longitude)
+}
+
+// Apparent altitude (h_a) of the sun as seen by the observer, raised by the
+// refraction in the air at the observer. As in SPA, the refraction is left out
+// while the solar disk is below the horizon.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) AltitudeAt(jd float64, o Observer) (float64, error) {
+	if err := o.Validate(); err != nil {
+		return 0, err
+	}
+
+	h, err := s.Altitude(jd, o.Latitude, o.Longitude)
+	if err != nil {
+		return 0, err
+	}
+
+	return o.apparent(s.Body, h)
+}
+
+// Returns the apparent altitude of the sun at true altitude h.
+func (o Observer) apparent(b Body, h float64) (float64, error) {
+	if b != Earth {
+		return h, nil
+	}
+
+	h_0, err := o.Refraction.True(0, o.Pressure, o.Temperature)
+	if err != nil {
+		return 0, err
+	}
+	if h < h_0-d_SunEarth/2 {
+		return h, nil
+	}
+
+	return o.Refraction.Apparent(h, o.Pressure, o.Temperature)
+}
+
+// Returns the altitude of the center of the solar disk, as a function of its
+// azimuth, at which the top of the disk touches the horizon of the observer.
+func (s Sky) horizon(o Observer) (func(A float64) float64, error) {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return nil, err
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Checked once here, so the model cannot fail during the search.
+	if _, err := o.Refraction.True(0, o.Pressure, o.Temperature); err != nil {
+		return nil, err
+	}
+
+	dip := o.dip(s.Body, bp)
+
+	return func(A float64) float64 {
+		h := -dip
+		if o.Horizon != nil {
+			h = o.Horizon(A)
+		}
+
+		if s.Body != Earth {
+			return h + bp.h_0
+		}
+
+		h, _ = o.Refraction.True(h, o.Pressure, o.Temperature)
+
+		return h - bp.d_Sun/2
+	}, nil
+}
+
+// Sunrise time (J_rise) is the moment at which the top of the solar disk
+// touches the horizon of the observer in the morning, taking into account the
+// dip of the horizon, the refraction in the air at the observer and solar disk
+// size. ErrPolarNight or ErrMidnightSun is returned when the sun does not rise
+// on that day.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) SunriseTimeAt(jd float64, o Observer) (float64, error) {
+	h_0, err := s.horizon(o)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := s.crossingTime(jd, h_0, Rising, o.Latitude, o.Longitude)
+
+	return r.JD, err
+}
+
+// Sunset time (J_set) is the moment at which the top of the solar disk touches
+// the horizon of the observer in the evening, taking into account the dip of
+// the horizon, the refraction in the air at the observer and solar disk size.
+// ErrPolarNight or ErrMidnightSun is returned when the sun does not set on that
+// day.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) SunsetTimeAt(jd float64, o Observer) (float64, error) {
+	h_0, err := s.horizon(o)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := s.crossingTime(jd, h_0, Setting, o.Latitude, o.Longitude)
+
+	return r.JD, err
+}
+
+// AzimuthAt is Sky.AzimuthAt on the analytic ephemeris.
+func (b Body) AzimuthAt(jd float64, o Observer) (float64, error) {
+	return b.With(Analytic).AzimuthAt(jd, o)
+}
+
+// AltitudeAt is Sky.AltitudeAt on the analytic ephemeris.
+func (b Body) AltitudeAt(jd float64, o Observer) (float64, error) {
+	return b.With(Analytic).AltitudeAt(jd, o)
+}
+
+// SunriseTimeAt is Sky.SunriseTimeAt on the analytic ephemeris.
+func (b Body) SunriseTimeAt(jd float64, o Observer) (float64, error) {
+	return b.With(Analytic).SunriseTimeAt(jd, o)
+}
+
+// SunsetTimeAt is Sky.SunsetTimeAt on the analytic ephemeris.
+func (b Body) SunsetTimeAt(jd float64, o Observer) (float64, error) {
+	return b.With(Analytic).SunsetTimeAt(jd, o)
+}
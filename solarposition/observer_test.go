@@ -0,0 +1,119 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Observer tests: a sea level observer in the standard atmosphere sees the sun
+// rise and set within seconds of the conventional h_0.
+func TestObserverStandard(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	for _, jd := range []float64{2453097.0, 2453177.5, 2453280.0, 2453360.0} {
+		J_rise, _ := Earth.SunriseTime(jd, 52, -5.0)
+		actual, err := Earth.SunriseTimeAt(jd, o)
+		assert.NoError(t, err)
+		assert.InDelta(t, J_rise, actual, 10.0/86400)
+
+		J_set, _ := Earth.SunsetTime(jd, 52, -5.0)
+		actual, err = Earth.SunsetTimeAt(jd, o)
+		assert.NoError(t, err)
+		assert.InDelta(t, J_set, actual, 10.0/86400)
+	}
+}
+
+// Observer tests: shift of sunrise and sunset (in seconds) from sea level in
+// the standard atmosphere.
+func TestObserverEvents(t *testing.T) {
+	east := func(A float64) float64 {
+		if A < 0 {
+			return 5
+		}
+		return 0
+	}
+
+	tests := []struct {
+		name string
+		b    Body
+		o    Observer
+		rise float64
+		set  float64
+		err  error
+	}{
+		{"MountainTop", Earth, Observer{52, -5.0, 3000, 700, -5, Bennett, nil}, -673.5, 676.1, nil},
+		{"Aircraft", Earth, Observer{52, -5.0, 10000, 265, -50, Bennett, nil}, -1124.3, 1128.6, nil},
+		{"NoAir", Earth, Observer{52, -5.0, 0, 0, 0, Bennett, nil}, 226.0, -226.5, nil},
+		{"EasternRidge", Earth, Observer{52, -5.0, 0, 1010, 10, Bennett, east}, 2115.5, 0, nil},
+		{"MarsCrater", Mars, Observer{52, -5.0, 3000, 0, 0, Bennett, nil}, -981.9, 983.0, nil},
+		{"NOAA", Earth, Observer{52, -5.0, 0, 1010, 10, NOAA, nil}, -0.1, 0.1, nil},
+		{"NoRefraction", Earth, Observer{52, -5.0, 0, 1010, 10, NoRefraction, nil}, 226.0, -226.5, nil},
+		{"InvalidRefraction", Earth, Observer{52, -5.0, 0, 1010, 10, Refraction(9), nil}, 0, 0, ErrInvalidRefraction},
+		{"InvalidPlanet", Body(23), NewObserver(52, -5.0), 0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flat := NewObserver(52, -5.0)
+
+			J_rise, _ := tt.b.SunriseTimeAt(2453097.0, flat)
+			actual, err := tt.b.SunriseTimeAt(2453097.0, tt.o)
+			assert.Equal(t, tt.err, err)
+			if err == nil {
+				assert.InDelta(t, tt.rise, (actual-J_rise)*86400, 1)
+			}
+
+			J_set, _ := tt.b.SunsetTimeAt(2453097.0, flat)
+			actual, err = tt.b.SunsetTimeAt(2453097.0, tt.o)
+			assert.Equal(t, tt.err, err)
+			if err == nil {
+				assert.InDelta(t, tt.set, (actual-J_set)*86400, 1)
+			}
+		})
+	}
+}
+
+// Observer tests: refraction raises the sun while it is above the horizon.
+func TestObserverAltitude(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   float64
+		b    Body
+		o    Observer
+		dh   float64
+	}{
+		{"Noon", 2453097.0, Earth, NewObserver(52, -5.0), 0.0180},
+		{"Evening", 2453097.25, Earth, NewObserver(52, -5.0), 0.3188},
+		{"Cold", 2453097.25, Earth, Observer{52, -5.0, 0, 1030, -30, Bennett, nil}, 0.3731},
+		{"Night", 2453097.5, Earth, NewObserver(52, -5.0), 0},
+		{"Mars", 2453097.25, Mars, NewObserver(52, -5.0), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := tt.b.Altitude(tt.jd, tt.o.Latitude, tt.o.Longitude)
+			actual, err := tt.b.AltitudeAt(tt.jd, tt.o)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.dh, actual-h, 1e-4)
+
+			A, _ := tt.b.Azimuth(tt.jd, tt.o.Latitude, tt.o.Longitude)
+			actual, err = tt.b.AzimuthAt(tt.jd, tt.o)
+			assert.NoError(t, err)
+			assert.Equal(t, A, actual)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+
+	"github.com/codymj/celestia/nutation"
+	"github.com/codymj/celestia/sidereal"
+)
+
+// Position is the position of the sun seen by an observer at one moment, in
+// each of the coordinate systems of the package.
+type Position struct {
+	// Julian day (UT1) of the position.
+	JD float64
+
+	// Ecliptic longitude (l) and latitude (beta).
+	EclipticLongitude, EclipticLatitude float64
+
+	// Right ascension (a) and declination (d).
+	RightAscension, Declination float64
+
+	// Sidereal time (theta) and hour angle (H), between −180° and 180°.
+	SiderealTime, HourAngle float64
+
+	// Azimuth (A), altitude (h) and apparent altitude (h_a) as in AltitudeAt.
+	Azimuth, Altitude, ApparentAltitude float64
+
+	// Distance (r) between the body and the sun (in AU).
+	Distance float64
+}
+
+// Position calculates every coordinate of the sun seen by the observer at
+// once. On the analytic ephemeris the mean anomaly, the equation of center and
+// the ecliptic longitude are calculated a single time and shared by all the
+// other coordinates, where calling Azimuth and Altitude calculates each of them
+// twice. Other ephemerides are called once for the equatorial coordinates; the
+// ecliptic coordinates are rotated back from them, and the distance is the
+// analytic one.
+//
+// jd: julian day (UT1).
+//
+// o: observer.
+func (s Sky) Position(jd float64, o Observer) (Position, error) {
+	if err := o.Validate(); err != nil {
+		return Position{}, err
+	}
+
+	p, err := s.instant(jd)
+	if err != nil {
+		return Position{}, err
+	}
+	x, y := s.pole(jd)
+
+	return p.at(s.Body, o, x, y)
+}
+
+// Returns the part of the position of the sun which is the same for every
+// observer: all but the hour angle and the horizontal coordinates, with the
+// sidereal time at longitude 0.
+//
+// jd: julian day (UT1).
+func (s Sky) instant(jd float64) (Position, error) {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return Position{}, err
+	}
+
+	// ΔT is looked up a single time, for the sidereal time and the orbit.
+	jde := s.dynamicalTime(jd)
+	theta, err := s.siderealTime(jd, jde, 0, 0)
+	if err != nil {
+		return Position{}, err
+	}
+
+	p := Position{JD: jd, SiderealTime: theta}
+	M := bp.meanAnomaly(jde)
+	C := bp.equationOfCenter(M)
+	p.Distance = bp.distance(M + C)
+
+	if s.ephemeris() == Analytic {
+		p.EclipticLongitude = bp.eclipticLongitude(M, C)
+		p.RightAscension, p.Declination = equatorial(p.EclipticLongitude, 0, bp.E)
+
+		return p, nil
+	}
+
+	p.RightAscension, p.Declination, err = s.ephemeris().Equatorial(s.Body, jde)
+	if err != nil {
+		return Position{}, err
+	}
+
+	// Other ephemerides of the Earth refer to the true equator of date.
+	e := bp.E
+	if s.Body == Earth {
+		e = nutation.TrueObliquity(jde)
+	}
+	p.EclipticLongitude, p.EclipticLatitude = ecliptic(p.RightAscension, p.Declination, e)
+
+	return p, nil
+}
+
+// Completes the position of the sun at an instant for a valid observer, with
+// the coordinates x and y of the pole in arcseconds.
+func (p Position) at(b Body, o Observer, x, y float64) (Position, error) {
+	lon := o.Longitude + sidereal.PolarMotionCorrection(o.Latitude, o.Longitude, x, y)
+	p.SiderealTime = math.Mod(p.SiderealTime-lon, 360.0)
+	if p.SiderealTime < 0 {
+		p.SiderealTime += 360.0
+	}
+
+	lat := o.Latitude
+	p.HourAngle = normalize180(p.SiderealTime - p.RightAscension)
+	p.Azimuth = azimuth(p.HourAngle, p.Declination, lat)
+	p.Altitude = altitude(p.HourAngle, p.Declination, lat)
+
+	var err error
+	p.ApparentAltitude, err = o.apparent(b, p.Altitude)
+	if err != nil {
+		return Position{}, err
+	}
+
+	return p, nil
+}
+
+// Returns the ecliptic longitude, between 0° and 360°, and latitude of the sun
+// at right ascension a and declination d, for an equator inclined by e on the
+// ecliptic.
+func ecliptic(a, d, e float64) (float64, float64) {
+	l := math.Atan2(math.Sin(a*RAD)*math.Cos(e*RAD)+math.Tan(d*RAD)*math.Sin(e*RAD),
+		math.Cos(a*RAD)) * DEG
+	if l < 0 {
+		l += 360
+	}
+	beta := math.Asin(math.Sin(d*RAD)*math.Cos(e*RAD)-
+		math.Cos(d*RAD)*math.Sin(e*RAD)*math.Sin(a*RAD)) * DEG
+
+	return l, beta
+}
+
+// Position is Sky.Position on the analytic ephemeris.
+func (b Body) Position(jd float64, o Observer) (Position, error) {
+	return b.With(Analytic).Position(jd, o)
+}
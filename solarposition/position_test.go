@@ -0,0 +1,153 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Position tests: every coordinate matches the function calculating it alone.
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Sky
+		jd   float64
+		o    Observer
+	}{
+		{"Earth", Earth.With(Analytic), 2453097.25, NewObserver(52, -5.0)},
+		{"EarthVSOP87", Earth.With(VSOP87), 2453097.25, NewObserver(52, -5.0)},
+		{"Mars", Mars.With(Analytic), 2453097.25, NewObserver(-30, 120)},
+		{"Venus", Venus.With(Analytic), 2453097.75, NewObserver(10, 0)},
+		{"Pluto", Pluto.With(Analytic), 2453097.0, NewObserver(70, -45)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.s.Position(tt.jd, tt.o)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.jd, p.JD)
+
+			b, jde := tt.s.Body, tt.s.dynamicalTime(tt.jd)
+			m := tt.s.Ephemeris.(Model)
+			l, beta, _ := b.EclipticCoordinates(jde, m)
+			assert.InDelta(t, l, p.EclipticLongitude, 1e-9)
+			assert.InDelta(t, beta, p.EclipticLatitude, 1e-9)
+			a, d, _ := b.EquatorialCoordinates(jde, m)
+			assert.InDelta(t, a, p.RightAscension, 1e-9)
+			assert.InDelta(t, d, p.Declination, 1e-9)
+			r, _ := b.Distance(jde)
+			assert.Equal(t, r, p.Distance)
+
+			theta, _ := tt.s.siderealTime(tt.jd, jde, tt.o.Latitude, tt.o.Longitude)
+			assert.InDelta(t, theta, p.SiderealTime, 1e-9)
+			H, _ := tt.s.HourAngle(tt.jd, tt.o.Longitude)
+			assert.InDelta(t, normalize180(H), p.HourAngle, 1e-9)
+			A, h, _ := tt.s.Horizontal(tt.jd, tt.o.Latitude, tt.o.Longitude)
+			assert.InDelta(t, A, p.Azimuth, 1e-9)
+			assert.InDelta(t, h, p.Altitude, 1e-9)
+			h_a, _ := tt.s.AltitudeAt(tt.jd, tt.o)
+			assert.InDelta(t, h_a, p.ApparentAltitude, 1e-9)
+		})
+	}
+}
+
+// Position tests: errors.
+func TestPositionErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Body
+		o    Observer
+		err  error
+	}{
+		{"InvalidPlanet", Body(23), NewObserver(52, -5.0), ErrInvalidEnum},
+		{"InvalidLatitude", Earth, NewObserver(91, -5.0), ErrOutOfRange},
+		{"InvalidLongitude", Earth, NewObserver(52, math.NaN()), ErrOutOfRange},
+		{"InvalidRefraction", Earth, Observer{52, -5.0, 0, 1010, 10, Refraction(9), nil}, ErrInvalidRefraction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.b.Position(2453097.0, tt.o)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, Position{}, p)
+		})
+	}
+}
+
+// Distance tests: the Earth is closest to the sun in early January and
+// farthest in early July.
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name     string
+		jd       float64
+		b        Body
+		expected float64
+		err      error
+	}{
+		{"Perihelion", 2460313.5, Earth, 0.9833, nil},
+		{"Aphelion", 2460496.5, Earth, 1.0167, nil},
+		{"MercuryPerihelion", 2451590.3, Mercury, 0.3075, nil},
+		{"InvalidPlanet", 2460313.5, Body(23), 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.b.Distance(tt.jd)
+			assert.Equal(t, tt.err, err)
+			assert.InDelta(t, tt.expected, actual, 1e-4)
+		})
+	}
+}
+
+// Position benchmarks: the geometric azimuth and altitude called apart and
+// together, and every coordinate of a Position from the separate calls and
+// from a single call.
+func BenchmarkAzimuthAltitude(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Earth.Azimuth(2453097.25, 52, -5.0)
+		_, _ = Earth.Altitude(2453097.25, 52, -5.0)
+	}
+}
+
+func BenchmarkHorizontal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Earth.Horizontal(2453097.25, 52, -5.0)
+	}
+}
+
+func BenchmarkCoordinates(b *testing.B) {
+	o := NewObserver(52, -5.0)
+	for i := 0; i < b.N; i++ {
+		jde := Sky{}.dynamicalTime(2453097.25)
+		_, _ = Earth.EclipticLongitude(jde)
+		_, _ = Earth.RightAscension(jde)
+		_, _ = Earth.Declination(jde)
+		_, _ = Earth.Distance(jde)
+		_, _ = Earth.SiderealTime(2453097.25, o.Longitude)
+		_, _ = Earth.HourAngle(2453097.25, o.Longitude)
+		_, _ = Earth.AzimuthAt(2453097.25, o)
+		_, _ = Earth.AltitudeAt(2453097.25, o)
+	}
+}
+
+func BenchmarkPosition(b *testing.B) {
+	o := NewObserver(52, -5.0)
+	for i := 0; i < b.N; i++ {
+		_, _ = Earth.Position(2453097.25, o)
+	}
+}
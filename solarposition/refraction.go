@@ -0,0 +1,186 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import "math"
+
+// Refraction is the model of the atmospheric refraction, which raises the sun
+// above its true (geometric) altitude to its apparent altitude.
+type Refraction int
+
+const (
+	// Bennett gives the refraction from the apparent altitude, to about 0.07′.
+	Bennett Refraction = iota
+
+	// Saemundsson gives the refraction from the true altitude and is
+	// consistent with Bennett to about 0.1′.
+	Saemundsson
+
+	// Meeus is Bennett with the correction of Meeus, to about 0.015′.
+	Meeus
+
+	// NOAA is the piecewise model of the NOAA solar calculator, from the true
+	// altitude. Unlike the others it tapers off below the horizon.
+	NOAA
+
+	// NoRefraction leaves the altitude unchanged.
+	NoRefraction
+)
+
+// String returns the name of the refraction model.
+func (r Refraction) String() string {
+	switch r {
+	case Bennett:
+		return "Bennett"
+	case Saemundsson:
+		return "Saemundsson"
+	case Meeus:
+		return "Meeus"
+	case NOAA:
+		return "NOAA"
+	case NoRefraction:
+		return "none"
+	default:
+		return "invalid"
+	}
+}
+
+// Returns the ratio of the refraction at pressure P (in mbar) and temperature T
+// (in °C) to the refraction in the standard atmosphere.
+func atmosphere(P, T float64) float64 {
+	return (P / standardPressure) *
+		((273 + standardTemperature) / (273 + T))
+}
+
+// Returns the refraction (in degrees) in the standard atmosphere of the model,
+// and whether it is a function of the apparent rather than the true altitude.
+// The formulas of Bennett and Saemundsson stop being meaningful below the
+// horizon, so they are held at their value for an altitude of -1°.
+func (r Refraction) formula() (func(h float64) float64, bool, error) {
+	switch r {
+	case Bennett:
+		return bennett, true, nil
+	case Saemundsson:
+		return func(h float64) float64 {
+			h = math.Max(h, -1)
+
+			return 1.02 / math.Tan((h+10.3/(h+5.11))*RAD) / 60
+		}, false, nil
+	case Meeus:
+		return func(h float64) float64 {
+			R := bennett(h) * 60
+
+			return (R - 0.06*math.Sin((14.7*R+13)*RAD)) / 60
+		}, true, nil
+	case NOAA:
+		return noaaRefraction, false, nil
+	case NoRefraction:
+		return func(float64) float64 { return 0 }, false, nil
+	default:
+		return nil, false, ErrInvalidRefraction
+	}
+}
+
+// Returns the refraction (in degrees) at apparent altitude h (Bennett).
+func bennett(h float64) float64 {
+	h = math.Max(h, -1)
+
+	return 1 / math.Tan((h+7.31/(h+4.4))*RAD) / 60
+}
+
+// Returns the refraction (in degrees) at true altitude h of the NOAA solar
+// calculator.
+func noaaRefraction(h float64) float64 {
+	t := math.Tan(h * RAD)
+
+	var arcsec float64
+	switch {
+	case h > 85:
+		arcsec = 0
+	case h > 5:
+		arcsec = 58.1/t - 0.07/(t*t*t) + 0.000086/(t*t*t*t*t)
+	case h > -0.575:
+		arcsec = 1735 + h*(-518.2+h*(103.4+h*(-12.79+h*0.711)))
+	default:
+		arcsec = -20.772 / t
+	}
+
+	return arcsec / 3600
+}
+
+// Returns the altitude x at which x + sign*R(x) equals h, by Newton's method.
+func invert(R func(float64) float64, sign, h float64) float64 {
+	const step = 1e-6
+
+	x := h
+	for i := 0; i < maxIterations; i++ {
+		g := x + sign*R(x) - h
+		dg := 1 + sign*(R(x+step)-R(x))/step
+
+		dx := g / dg
+		x -= dx
+		if math.Abs(dx) < 1e-10 {
+			break
+		}
+	}
+
+	return x
+}
+
+// Apparent altitude (h_a) of the sun at true altitude h, for air at pressure P
+// (in mbar) and temperature T (in °C).
+//
+// h: true altitude.
+//
+// P: pressure.
+//
+// T: temperature.
+func (r Refraction) Apparent(h, P, T float64) (float64, error) {
+	R, apparent, err := r.formula()
+	if err != nil {
+		return 0, err
+	}
+
+	f := atmosphere(P, T)
+	scaled := func(h float64) float64 { return R(h) * f }
+	if apparent {
+		return invert(scaled, -1, h), nil
+	}
+
+	return h + scaled(h), nil
+}
+
+// True altitude (h) of the sun at apparent altitude h_a, for air at pressure P
+// (in mbar) and temperature T (in °C).
+//
+// h_a: apparent altitude.
+//
+// P: pressure.
+//
+// T: temperature.
+func (r Refraction) True(h_a, P, T float64) (float64, error) {
+	R, apparent, err := r.formula()
+	if err != nil {
+		return 0, err
+	}
+
+	f := atmosphere(P, T)
+	scaled := func(h float64) float64 { return R(h) * f }
+	if apparent {
+		return h_a - scaled(h_a), nil
+	}
+
+	return invert(scaled, 1, h_a), nil
+}
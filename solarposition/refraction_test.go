@@ -0,0 +1,103 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Refraction tests: refraction (in arcminutes) in the standard atmosphere.
+func TestRefraction(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Refraction
+		h    float64
+		R    float64
+		R_a  float64
+		err  error
+	}{
+		{"Bennett", Bennett, 0, 28.9332, 34.4775, nil},
+		{"Saemundsson", Saemundsson, 0, 28.9819, 34.4325, nil},
+		{"Meeus", Meeus, 0, 28.8829, 34.4568, nil},
+		{"NOAA", NOAA, 0, 28.9167, 34.4931, nil},
+		{"BennettHigh", Bennett, 10, 5.3467, 5.3915, nil},
+		{"NOAAHigh", NOAA, 10, 5.2873, 5.3320, nil},
+		{"None", NoRefraction, 0, 0, 0, nil},
+		{"Invalid", Refraction(9), 0, 0, 0, ErrInvalidRefraction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h_a, err := tt.r.Apparent(tt.h, 1010, 10)
+			assert.Equal(t, tt.err, err)
+			if err == nil {
+				assert.InDelta(t, tt.R, (h_a-tt.h)*60, 1e-4)
+			}
+
+			h, err := tt.r.True(tt.h, 1010, 10)
+			assert.Equal(t, tt.err, err)
+			if err == nil {
+				assert.InDelta(t, tt.R_a, (tt.h-h)*60, 1e-4)
+			}
+		})
+	}
+}
+
+// Refraction tests: the models agree above the horizon and convert back and
+// forth between true and apparent altitude, short of the cut-off of the NOAA
+// model at 85°.
+func TestRefractionConsistency(t *testing.T) {
+	for _, r := range []Refraction{Bennett, Saemundsson, Meeus, NOAA} {
+		for h := -2.0; h < 85; h += 0.5 {
+			h_a, err := r.Apparent(h, 1010, 10)
+			assert.NoError(t, err)
+
+			actual, err := r.True(h_a, 1010, 10)
+			assert.NoError(t, err)
+			assert.InDelta(t, h, actual, 1e-8, "%v at %v°", r, h)
+
+			if h >= 0 {
+				expected, _ := Bennett.Apparent(h, 1010, 10)
+				assert.InDelta(t, expected, h_a, 0.1/60, "%v at %v°", r, h)
+			}
+		}
+	}
+}
+
+// Refraction tests: the refraction scales with pressure and temperature.
+func TestRefractionAtmosphere(t *testing.T) {
+	standard, _ := NOAA.Apparent(10, 1010, 10)
+
+	actual, err := NOAA.Apparent(10, 2020, 10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2*(standard-10), actual-10, 1e-12)
+
+	actual, err = NOAA.Apparent(10, 1010, -10)
+	assert.NoError(t, err)
+	assert.InDelta(t, (standard-10)*283/263, actual-10, 1e-12)
+
+	actual, err = Bennett.Apparent(10, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, actual)
+}
+
+// Refraction tests.
+func TestRefractionString(t *testing.T) {
+	assert.Equal(t, "Saemundsson", Saemundsson.String())
+	assert.Equal(t, "none", NoRefraction.String())
+	assert.Equal(t, "invalid", Refraction(9).String())
+}
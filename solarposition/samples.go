@@ -0,0 +1,111 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"iter"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+// Sample is the position of the sun seen by an observer at one moment.
+type Sample struct {
+	Time time.Time
+
+	// Julian day (UT1) of the sample.
+	JD float64
+
+	// Hour angle (H), between −180° and 180°, and declination (d).
+	HourAngle, Declination float64
+
+	// Azimuth (A) and altitude (h).
+	Azimuth, Altitude float64
+
+	// Apparent altitude (h_a), raised by the refraction in the air at the
+	// observer as in AltitudeAt.
+	ApparentAltitude float64
+}
+
+// Samples returns an iterator over the position of the sun seen by the
+// observer from start up to, but not including, end at a fixed step. Each
+// sample takes a single call to the ephemeris, from which the hour angle,
+// azimuth and altitude are all derived, and nothing is held in memory between
+// samples. An error is yielded once with a zero Sample, after which the
+// iteration stops.
+//
+// start: time of the first sample.
+//
+// end: time at which the samples end.
+//
+// step: time between samples.
+//
+// o: observer.
+func (s Sky) Samples(start, end time.Time, step time.Duration, o Observer) iter.Seq2[Sample, error] {
+	return func(yield func(Sample, error) bool) {
+		if step <= 0 {
+			yield(Sample{}, ErrInvalidStep)
+			return
+		}
+		if err := o.Validate(); err != nil {
+			yield(Sample{}, err)
+			return
+		}
+
+		lat, lon := o.Latitude, o.Longitude
+
+		// Stepping by index keeps the rounding of each step from adding up
+		// over a long series.
+		for i := time.Duration(0); ; i++ {
+			t := start.Add(i * step)
+			if !t.Before(end) {
+				return
+			}
+
+			jd := julian.ToJulianDay(t.UTC())
+			H, d, err := s.hourAngle(jd, lat, lon)
+			if err != nil {
+				yield(Sample{}, err)
+				return
+			}
+			H = normalize180(H)
+
+			h := altitude(H, d, lat)
+			h_a, err := o.apparent(s.Body, h)
+			if err != nil {
+				yield(Sample{}, err)
+				return
+			}
+
+			sample := Sample{
+				Time:             t,
+				JD:               jd,
+				HourAngle:        H,
+				Declination:      d,
+				Azimuth:          azimuth(H, d, lat),
+				Altitude:         h,
+				ApparentAltitude: h_a,
+			}
+			if !yield(sample, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Samples is Sky.Samples on the analytic ephemeris.
+func (b Body) Samples(start, end time.Time, step time.Duration, o Observer) iter.Seq2[Sample, error] {
+	return b.With(Analytic).Samples(start, end, step, o)
+}
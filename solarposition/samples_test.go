@@ -0,0 +1,92 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/stretchr/testify/assert"
+)
+
+// Samples tests: each sample matches the single position functions.
+func TestSamples(t *testing.T) {
+	o := NewObserver(52, -5.0)
+	start := time.Date(2004, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	n := 0
+	for sample, err := range Earth.Samples(start, end, 10*time.Minute, o) {
+		assert.NoError(t, err)
+		assert.Equal(t, start.Add(time.Duration(n)*10*time.Minute), sample.Time)
+		assert.Equal(t, julian.ToJulianDay(sample.Time), sample.JD)
+
+		H, _ := Earth.HourAngle(sample.JD, o.Longitude)
+		assert.InDelta(t, normalize180(H), sample.HourAngle, 1e-9)
+		d, _ := Earth.Declination(Sky{}.dynamicalTime(sample.JD))
+		assert.InDelta(t, d, sample.Declination, 1e-9)
+		A, _ := Earth.AzimuthAt(sample.JD, o)
+		assert.InDelta(t, A, sample.Azimuth, 1e-9)
+		h, _ := Earth.Altitude(sample.JD, o.Latitude, o.Longitude)
+		assert.InDelta(t, h, sample.Altitude, 1e-9)
+		h_a, _ := Earth.AltitudeAt(sample.JD, o)
+		assert.InDelta(t, h_a, sample.ApparentAltitude, 1e-9)
+		n++
+	}
+	assert.Equal(t, 144, n)
+}
+
+// Samples tests: the iteration stops at the first error or when the loop
+// breaks.
+func TestSamplesStop(t *testing.T) {
+	start := time.Date(2004, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		b    Body
+		end  time.Time
+		step time.Duration
+		o    Observer
+		n    int
+		err  error
+	}{
+		{"Empty", Earth, start, time.Minute, NewObserver(52, -5.0), 0, nil},
+		{"InvalidStep", Earth, end, 0, NewObserver(52, -5.0), 1, ErrInvalidStep},
+		{"InvalidLatitude", Earth, end, time.Minute, NewObserver(91, -5.0), 1, ErrOutOfRange},
+		{"InvalidRefraction", Earth, end, time.Minute, Observer{52, -5.0, 0, 1010, 10, Refraction(9), nil}, 1, ErrInvalidRefraction},
+		{"InvalidPlanet", Body(23), end, time.Minute, NewObserver(52, -5.0), 1, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := 0
+			for _, err := range tt.b.Samples(start, tt.end, tt.step, tt.o) {
+				assert.ErrorIs(t, err, tt.err)
+				n++
+			}
+			assert.Equal(t, tt.n, n)
+		})
+	}
+
+	n := 0
+	for range Earth.Samples(start, end, time.Minute, NewObserver(52, -5.0)) {
+		if n++; n == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, n)
+}
@@ -16,8 +16,8 @@ package solarposition
 
 import (
 	"errors"
-	"fmt"
 	"math"
+	"time"
 
 	"github.com/codymj/celestia/julian"
 )
@@ -39,6 +39,12 @@ const (
 	M1Jupiter = 0.08308529
 	M0Saturn  = 317.0207
 	M1Saturn  = 0.03344414
+	M0Uranus  = 141.0498
+	M1Uranus  = 0.01172834
+	M0Neptune = 256.2250
+	M1Neptune = 0.00598103
+	M0Pluto   = 14.882
+	M1Pluto   = 0.00396
 
 	// ObliquityEcliptic
 	EMercury = 0.0351
@@ -47,6 +53,9 @@ const (
 	EMars    = 25.1918
 	EJupiter = 3.1189
 	ESaturn  = 26.7285
+	EUranus  = 82.2298
+	ENeptune = 27.8477
+	EPluto   = 119.6075
 
 	// PerihelionLongitude
 	PMercury = 230.3265
@@ -96,6 +105,24 @@ const (
 	C4Saturn  = 0.0006
 	C5Saturn  = 0.0000
 	C6Saturn  = 0.0000
+	C1Uranus  = 5.3042
+	C2Uranus  = 0.1534
+	C3Uranus  = 0.0062
+	C4Uranus  = 0.0003
+	C5Uranus  = 0.0000
+	C6Uranus  = 0.0000
+	C1Neptune = 1.0302
+	C2Neptune = 0.0058
+	C3Neptune = 0.0000
+	C4Neptune = 0.0000
+	C5Neptune = 0.0000
+	C6Neptune = 0.0000
+	C1Pluto   = 28.3150
+	C2Pluto   = 4.3408
+	C3Pluto   = 0.9214
+	C4Pluto   = 0.2235
+	C5Pluto   = 0.0627
+	C6Pluto   = 0.0174
 
 	// SiderealTime
 	T0Mercury = 132.3282
@@ -110,8 +137,93 @@ const (
 	T1Jupiter = 870.5360000
 	T0Saturn  = 174.3508
 	T1Saturn  = 810.7939024
+	T0Uranus  = 17.9691
+	T1Uranus  = -501.1600928
+	T0Neptune = 52.3542
+	T1Neptune = 536.3128662
+	T0Pluto   = 136.7844
+	T1Pluto   = -56.3625225
 
-	// SolarTransit
+	// Sunrise/Sunset
+	h_0Mercury   = -0.69
+	d_SunMercury = 1.38
+	h_0Venus     = -0.37
+	d_SunVenus   = 0.74
+	h_0Earth     = -0.83
+	d_SunEarth   = 0.53
+	h_0Mars      = -0.17
+	d_SunMars    = 0.35
+	h_0Jupiter   = -0.05
+	d_SunJupiter = 0.10
+	h_0Saturn    = -0.03
+	d_SunSaturn  = 0.06
+	h_0Uranus    = -0.01
+	d_SunUranus  = 0.03
+	h_0Neptune   = -0.01
+	d_SunNeptune = 0.02
+	h_0Pluto     = -0.01
+	d_SunPluto   = 0.01
+
+	// Mean radius (in km)
+	RMercury = 2439.7
+	RVenus   = 6051.8
+	REarth   = 6371.0
+	RMars    = 3389.5
+	RJupiter = 69911.0
+	RSaturn  = 58232.0
+	RUranus  = 25362.0
+	RNeptune = 24622.0
+	RPluto   = 1188.3
+
+	// Distance, semi-major axis (in AU) and eccentricity of the orbit
+	AMercury   = 0.38709927
+	EccMercury = 0.20563593
+	AVenus     = 0.72333566
+	EccVenus   = 0.00677672
+	AEarth     = 1.00000261
+	EccEarth   = 0.01671123
+	AMars      = 1.52371034
+	EccMars    = 0.09339410
+	AJupiter   = 5.20288700
+	EccJupiter = 0.04838624
+	ASaturn    = 9.53667594
+	EccSaturn  = 0.05386179
+	AUranus    = 19.18916464
+	EccUranus  = 0.04725744
+	ANeptune   = 30.06992276
+	EccNeptune = 0.00859048
+	APluto     = 39.48211675
+	EccPluto   = 0.24882730
+
+	// Pole of the equator, right ascension and declination in the ICRF at
+	// J2000 (IAU 2015)
+	PoleAMercury = 281.0103
+	PoleDMercury = 61.4155
+	PoleAVenus   = 272.76
+	PoleDVenus   = 67.16
+	PoleAEarth   = 0.0
+	PoleDEarth   = 90.0
+	PoleAMars    = 317.68143
+	PoleDMars    = 52.88650
+	PoleAJupiter = 268.056595
+	PoleDJupiter = 64.495303
+	PoleASaturn  = 40.589
+	PoleDSaturn  = 83.537
+	PoleAUranus  = 257.311
+	PoleDUranus  = -15.175
+	PoleANeptune = 299.36
+	PoleDNeptune = 43.46
+	PoleAPluto   = 132.993
+	PoleDPluto   = -6.163
+)
+
+// Coefficients of the transit of Astronomy Answers, rounded from the other
+// parameters of each body.
+//
+// Deprecated: the transit is derived from the mean anomaly, the perihelion,
+// the equation of center and the sidereal time of the body, and these values
+// are not used.
+const (
 	J0Mercury = 45.3497
 	J1Mercury = 11.4556
 	J2Mercury = 0.0000
@@ -136,28 +248,28 @@ const (
 	J1Saturn  = 0.0078
 	J2Saturn  = -0.0040
 	J3Saturn  = 0.4440276
-
-	// Sunrise/Sunset
-	h_0Mercury   = -0.69
-	d_SunMercury = 1.38
-	h_0Venus     = -0.37
-	d_SunVenus   = 0.74
-	h_0Earth     = -0.83
-	d_SunEarth   = 0.53
-	h_0Mars      = -0.17
-	d_SunMars    = 0.35
-	h_0Jupiter   = -0.05
-	d_SunJupiter = 0.10
-	h_0Saturn    = -0.03
-	d_SunSaturn  = 0.06
 )
 
 var (
-	ErrInvalidEnum = errors.New("invalid planet enum, see README")
+	ErrInvalidEnum       = errors.New("invalid planet enum, see README")
+	ErrPolarNight        = errors.New("sun stays below the horizon all day")
+	ErrMidnightSun       = errors.New("sun stays above the horizon all day")
+	ErrNoConvergence     = errors.New("event time did not converge")
+	ErrInvalidTwilight   = errors.New("invalid twilight")
+	ErrUnsupportedModel  = errors.New("model not supported for this body")
+	ErrInvalidRefraction = errors.New("invalid refraction model")
+	ErrOutOfRange        = errors.New("input out of range")
+	ErrInvalidBand       = errors.New("band must be higher at the top than the bottom")
+	ErrNotBracketed      = errors.New("root is not bracketed")
+	ErrInvalidStep       = errors.New("step must be positive")
 )
 
+// Maximum number of refinement steps taken by the event time calculations.
+const maxIterations = 50
+
 // Normalizes angles to be between -180 degrees and 180 degrees.
 func normalize180(angle float64) float64 {
+	angle = math.Mod(angle, 360.0)
 	if angle > 180.0 {
 		angle -= 360.0
 	} else if angle < -180.0 {
@@ -167,228 +279,141 @@ func normalize180(angle float64) float64 {
 	return angle
 }
 
+// Transforms a julian day (UT1) into a julian day (TT). Sidereal time follows
+// the rotation of the planet and is measured in UT1, while the orbital formulas
+// run on dynamical time; the two are ΔT apart, as given by the time scales.
+func dynamicalTime(c julian.TimeScales, jd float64) float64 {
+	return jd + c.DeltaT(julian.FromJulianDay(jd, time.UTC))/julian.SecondsPerDay
+}
+
 // Mean anomaly (M) calculates the position that the planet would have relative
 // to its perihelion if the orbit were a circle.
 //
-// jd: julian day.
-//
-// p: enum of planet (see README).
-func MeanAnomaly(jd float64, p int) (float64, error) {
-	var M float64
-	var err error
-
-	switch p {
-	case 0:
-		M = math.Mod(M0Mercury+M1Mercury*(jd-julian.J2000), 360.0)
-	case 1:
-		M = math.Mod(M0Venus+M1Venus*(jd-julian.J2000), 360.0)
-	case 2:
-		M = math.Mod(M0Earth+M1Earth*(jd-julian.J2000), 360.0)
-	case 3:
-		M = math.Mod(M0Mars+M1Mars*(jd-julian.J2000), 360.0)
-	case 4:
-		M = math.Mod(M0Jupiter+M1Jupiter*(jd-julian.J2000), 360.0)
-	case 5:
-		M = math.Mod(M0Saturn+M1Saturn*(jd-julian.J2000), 360.0)
-	default:
-		err = ErrInvalidEnum
+// jd: julian day (TT).
+func (b Body) MeanAnomaly(jd float64) (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
 	}
 
-	return M, err
+	return bp.meanAnomaly(jd), nil
+}
+
+// Returns the mean anomaly of the body at julian day jd (TT).
+func (bp *parameters) meanAnomaly(jd float64) float64 {
+	return math.Mod(bp.M0+bp.M1*(jd-julian.J2000), 360.0)
 }
 
 // Obliquity ecliptic (e) is the angle between the ecliptic and the celestial
 // equator of the planet.
-//
-// p: enum of planet (see README).
-func ObliquityEcliptic(p int) (float64, error) {
-	var e float64
-	var err error
+func (b Body) ObliquityEcliptic() (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
+	}
 
-	switch p {
-	case 0:
-		e = EMercury
-	case 1:
-		e = EVenus
-	case 2:
-		e = EEarth
-	case 3:
-		e = EMars
-	case 4:
-		e = EJupiter
-	case 5:
-		e = ESaturn
-	default:
-		err = ErrInvalidEnum
+	return bp.E, nil
+}
+
+// Pole returns the north pole of the equator of the body, as a right ascension
+// and a declination (in degrees) in the ICRF at J2000, from the IAU Working
+// Group on Cartographic Coordinates and Rotational Elements (2015). The north
+// pole is the one on the north side of the invariable plane, and for Pluto the
+// one given by the right-hand rule, which yields the obliquities of the
+// package. The slow motion of the poles is left out.
+func (b Body) Pole() (float64, float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, 0, err
 	}
 
-	return e, err
+	return bp.PoleA, bp.PoleD, nil
 }
 
 // Perihelion longitude (P) is the sum of the longitude of ascending node
 // (measured on the ecliptic plane) and the argument of periapsis (measured on
 // the orbital plane).
-//
-// p: enum of planet (see README).
-func PerihelionLongitude(p int) (float64, error) {
-	var P float64
-	var err error
-
-	switch p {
-	case 0:
-		P = PMercury
-	case 1:
-		P = PVenus
-	case 2:
-		P = PEarth
-	case 3:
-		P = PMars
-	case 4:
-		P = PJupiter
-	case 5:
-		P = PSaturn
-	default:
-		err = ErrInvalidEnum
+func (b Body) PerihelionLongitude() (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
 	}
 
-	return P, err
+	return bp.P, nil
 }
 
 // Equation of center (C) is the angular difference between the actual position
 // of a body in its elliptical orbit and the position it would occupy if its
 // motion were uniform.
 //
-// jd: julian day.
-//
-// p: enum of planet (see README).
-func EquationOfCenter(jd float64, p int) (float64, error) {
-	M, err := MeanAnomaly(jd, p)
+// jd: julian day (TT).
+func (b Body) EquationOfCenter(jd float64) (float64, error) {
+	bp, err := b.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	var C float64
-
-	calc := func(c1, c2, c3, c4, c5, c6, m float64) float64 {
-		return c1*math.Sin(m) + c2*math.Sin(2*m) + c3*math.Sin(3*m) +
-			c4*math.Sin(4*m) + c5*math.Sin(5*m) + c6*math.Sin(6*m)
-	}
-
-	switch p {
-	case 0:
-		C = calc(
-			C1Mercury, C2Mercury, C3Mercury,
-			C4Mercury, C5Mercury, C6Mercury,
-			M*RAD,
-		)
-	case 1:
-		C = calc(
-			C1Venus, C2Venus, C3Venus,
-			C4Venus, C5Venus, C6Venus,
-			M*RAD,
-		)
-	case 2:
-		C = calc(
-			C1Earth, C2Earth, C3Earth,
-			C4Earth, C5Earth, C6Earth,
-			M*RAD,
-		)
-	case 3:
-		C = calc(
-			C1Mars, C2Mars, C3Mars,
-			C4Mars, C5Mars, C6Mars,
-			M*RAD,
-		)
-	case 4:
-		C = calc(
-			C1Jupiter, C2Jupiter, C3Jupiter,
-			C4Jupiter, C5Jupiter, C6Jupiter,
-			M*RAD,
-		)
-	case 5:
-		C = calc(
-			C1Saturn, C2Saturn, C3Saturn,
-			C4Saturn, C5Saturn, C6Saturn,
-			M*RAD,
-		)
-	default:
-		err = ErrInvalidEnum
-	}
-
-	return C, err
+	return bp.equationOfCenter(bp.meanAnomaly(jd)), nil
+}
+
+// Returns the equation of center of the body at mean anomaly M.
+func (bp *parameters) equationOfCenter(M float64) float64 {
+	m := M * RAD
+
+	return bp.C[0]*math.Sin(m) + bp.C[1]*math.Sin(2*m) + bp.C[2]*math.Sin(3*m) +
+		bp.C[3]*math.Sin(4*m) + bp.C[4]*math.Sin(5*m) + bp.C[5]*math.Sin(6*m)
 }
 
 // True anomaly (v) is the sum of the mean anomaly (M) and the equation of
 // center (C).
 //
-// jd: julian day.
-//
-// p: enum of planet (see README).
-func TrueAnomaly(jd float64, p int) (float64, error) {
-	M, err := MeanAnomaly(jd, p)
+// jd: julian day (TT).
+func (b Body) TrueAnomaly(jd float64) (float64, error) {
+	bp, err := b.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	C, err := EquationOfCenter(jd, p)
-	if err != nil {
-		return 0, err
-	}
+	M := bp.meanAnomaly(jd)
 
-	return M + C, err
+	return M + bp.equationOfCenter(M), nil
 }
 
 // Ecliptic longitude (l) is the position along the ecliptic relative to the
 // vernal equinox (in degrees).
 //
-// jd: julian day.
-//
-// p: enum of planet (see README).
-func EclipticLongitude(jd float64, p int) (float64, error) {
-	M, err := MeanAnomaly(jd, p)
+// jd: julian day (TT).
+func (b Body) EclipticLongitude(jd float64) (float64, error) {
+	bp, err := b.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	w, err := PerihelionLongitude(p)
-	if err != nil {
-		return 0, err
-	}
+	M := bp.meanAnomaly(jd)
 
-	C, err := EquationOfCenter(jd, p)
-	if err != nil {
-		return 0, err
-	}
+	return bp.eclipticLongitude(M, bp.equationOfCenter(M)), nil
+}
 
-	L := M + w + 180
+// Returns the ecliptic longitude of the sun at mean anomaly M and equation of
+// center C.
+func (bp *parameters) eclipticLongitude(M, C float64) float64 {
+	L := M + bp.P + 180
 
 	l := L + C
 	for l > 360.0 {
 		l = math.Mod(l, 360.0)
 	}
 
-	return l, err
+	return l
 }
 
 // Right ascension (a) is the angular distance of a celestial object's hour
 // circle east of the vernal equinox, measured along the celestial equator (in
 // degrees).
 //
-// jd: julian day.
-//
-// p: enum of planet (see README).
-func RightAscension(jd float64, p int) (float64, error) {
-	l, err := EclipticLongitude(jd, p)
-	if err != nil {
-		return 0, err
-	}
-
-	e, err := ObliquityEcliptic(p)
-	if err != nil {
-		return 0, err
-	}
-
-	a := math.Atan2(math.Sin(l*RAD)*math.Cos(e*RAD), math.Cos(l*RAD)) * DEG
+// jd: julian day (TT).
+func (b Body) RightAscension(jd float64) (float64, error) {
+	a, _, err := b.EquatorialCoordinates(jd, Analytic)
 
 	return a, err
 }
@@ -396,60 +421,68 @@ func RightAscension(jd float64, p int) (float64, error) {
 // Declination (d) determines from which parts of the planet the object can be
 // visible.
 //
-// jd: julian day.
+// jd: julian day (TT).
+func (b Body) Declination(jd float64) (float64, error) {
+	_, d, err := b.EquatorialCoordinates(jd, Analytic)
+
+	return d, err
+}
+
+// Returns the right ascension and the declination of the sun at ecliptic
+// longitude l and latitude beta, for an equator inclined by e on the ecliptic.
+func equatorial(l, beta, e float64) (float64, float64) {
+	a := math.Atan2(math.Sin(l*RAD)*math.Cos(e*RAD)-math.Tan(beta*RAD)*math.Sin(e*RAD),
+		math.Cos(l*RAD)) * DEG
+	d := math.Asin(math.Sin(beta*RAD)*math.Cos(e*RAD)+
+		math.Cos(beta*RAD)*math.Sin(e*RAD)*math.Sin(l*RAD)) * DEG
+
+	return a, d
+}
+
+// Distance (r) between the planet and the sun (in AU), from the true anomaly
+// on the Keplerian orbit of the planet.
 //
-// p: enum of the planet (see README).
-func Declination(jd float64, p int) (float64, error) {
-	l, err := EclipticLongitude(jd, p)
+// jd: julian day (TT).
+func (b Body) Distance(jd float64) (float64, error) {
+	bp, err := b.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	e, err := ObliquityEcliptic(p)
-	if err != nil {
-		return 0, err
-	}
+	M := bp.meanAnomaly(jd)
 
-	d := math.Atan(math.Sin(l*RAD)*math.Sin(e*RAD)) * DEG
+	return bp.distance(M + bp.equationOfCenter(M)), nil
+}
 
-	return d, err
+// Returns the distance between the body and the sun at true anomaly v.
+func (bp *parameters) distance(v float64) float64 {
+	return bp.A * (1 - bp.Ecc*bp.Ecc) / (1 + bp.Ecc*math.Cos(v*RAD))
 }
 
 // Sidereal time (theta) is the rotational angle of the planet at your location,
 // relative to the stars and the right ascension that is on the celestial
 // meridian at that moment.
 //
-// jd: julian day.
-//
-// p: enum of the planet (see README).
+// jd: julian day (UT1).
 //
 // lon: longitude (west).
-func SiderealTime(jd float64, p int, lon float64) (float64, error) {
-	var theta float64
-	var err error
-
-	switch p {
-	case 0:
-		theta = T0Mercury + T1Mercury*(jd-julian.J2000) - lon
-	case 1:
-		theta = T0Venus + T1Venus*(jd-julian.J2000) - lon
-	case 2:
-		theta = T0Earth + T1Earth*(jd-julian.J2000) - lon
-	case 3:
-		theta = T0Mars + T1Mars*(jd-julian.J2000) - lon
-	case 4:
-		theta = T0Jupiter + T1Jupiter*(jd-julian.J2000) - lon
-	case 5:
-		theta = T0Saturn + T1Saturn*(jd-julian.J2000) - lon
-	default:
-		err = ErrInvalidEnum
+func (b Body) SiderealTime(jd float64, lon float64) (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, err
 	}
 
-	for theta > 360.0 {
-		theta = math.Mod(theta, 360.0)
+	// Bodies with retrograde rotation have a negative T1, so theta has to be
+	// wrapped from below as well.
+	theta := math.Mod(bp.T0+bp.T1*(jd-julian.J2000)-lon, 360.0)
+	if theta < 0 {
+		theta += 360.0
 	}
 
-	return theta, err
+	return theta, nil
 }
 
 // Hour angle (H) of a celestial body is the difference in right ascension
@@ -457,143 +490,145 @@ func SiderealTime(jd float64, p int, lon float64) (float64, error) {
 // that time, indicating how long ago (measured in sidereal time) the celestial
 // body passed through the celestial meridian.
 //
-// jd: julian day.
+// Without a latitude, the longitude is not corrected for polar motion.
+//
+// jd: julian day (UT1).
+//
+// lon: longitude (west).
+func (s Sky) HourAngle(jd float64, lon float64) (float64, error) {
+	H, _, err := s.hourAngle(jd, 0, lon)
+
+	return H, err
+}
+
+// Returns the hour angle and the declination of the sun from a single call to
+// the ephemeris.
+//
+// jd: julian day (UT1).
 //
-// p: enum of the planet (see README).
+// lat: latitude (north)
 //
 // lon: longitude (west).
-func HourAngle(jd float64, p int, lon float64) (float64, error) {
-	theta, err := SiderealTime(jd, p, lon)
+func (s Sky) hourAngle(jd float64, lat, lon float64) (float64, float64, error) {
+	jde := s.dynamicalTime(jd)
+	theta, err := s.siderealTime(jd, jde, lat, lon)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	a, err := RightAscension(jd, p)
+	a, d, err := s.ephemeris().Equatorial(s.Body, jde)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	return theta - a, err
+	return theta - a, d, err
 }
 
 // Azimuth (A) is the coordinate from the horizontal coordinate system that
 // indicates the direction along the horizon. It is convenient to set 0° in the
 // south and to measure azimuth between −180° and 180°.
 //
-// jd: julian day.
-//
-// p: enum of the planet (see README).
+// jd: julian day (UT1).
 //
 // lat: latitude (north)
 //
 // lon: longitude (west).
-func Azimuth(jd float64, p int, lat, lon float64) (float64, error) {
-	d, err := Declination(jd, p)
-	if err != nil {
+func (s Sky) Azimuth(jd float64, lat, lon float64) (float64, error) {
+	if err := checkLatitude(lat); err != nil {
 		return 0, err
 	}
 
-	H, err := HourAngle(jd, p, lon)
+	H, d, err := s.hourAngle(jd, lat, lon)
 	if err != nil {
 		return 0, err
 	}
 
-	A := math.Atan2(
+	return azimuth(H, d, lat), err
+}
+
+// Returns the azimuth of the sun at hour angle H and declination d.
+func azimuth(H, d, lat float64) float64 {
+	return math.Atan2(
 		math.Sin(H*RAD),
 		math.Cos(H*RAD)*math.Sin(lat*RAD)-math.Tan(d*RAD)*math.Cos(lat*RAD),
 	) * DEG
-
-	return A, err
 }
 
 // Altitude (h) indicates how high above the horizon a celestial body is. It is
 // 0° at the horizon, 90° at its zenith (straight up) and -90° in the nadir (
 // straight down).
 //
-// jd: julian day.
-//
-// p: enum of the planet (see README).
+// jd: julian day (UT1).
 //
 // lat: latitude (north)
 //
 // lon: longitude (west).
-func Altitude(jd float64, p int, lat, lon float64) (float64, error) {
-	d, err := Declination(jd, p)
-	if err != nil {
+func (s Sky) Altitude(jd float64, lat, lon float64) (float64, error) {
+	if err := checkLatitude(lat); err != nil {
 		return 0, err
 	}
 
-	H, err := HourAngle(jd, p, lon)
+	H, d, err := s.hourAngle(jd, lat, lon)
 	if err != nil {
 		return 0, err
 	}
 
-	h := math.Asin(
+	return altitude(H, d, lat), err
+}
+
+// Returns the altitude of the sun at hour angle H and declination d.
+func altitude(H, d, lat float64) float64 {
+	return math.Asin(
 		math.Sin(lat*RAD)*math.Sin(d*RAD)+
 			math.Cos(lat*RAD)*math.Cos(d*RAD)*math.Cos(H*RAD),
 	) * DEG
+}
 
-	return h, err
+// Returns the length of a solar day of the body (in earth days). It is negative
+// for bodies with retrograde rotation, on which the hour angle of the sun
+// decreases over time.
+func (bp *parameters) solarDay() float64 {
+	return 360.0 / (bp.T1 - bp.M1)
 }
 
 // Transit time (J_transit) of a celestial body is the moment at which the body
 // passes through the celestial meridian and is highest in the sky. The hour
 // angle (H) of the body is then 0.
 //
-// jd: julian day.
+// jd: julian day (UT1).
+//
+// lon: longitude (west).
+func (s Sky) TransitTime(jd float64, lon float64) (float64, error) {
+	r, err := s.Transit(jd, lon)
+
+	return r.JD, err
+}
+
+// Transit is the transit time (J_transit) together with the diagnostics of its
+// refinement by the solver of the sky.
 //
-// p: enum of the planet (see README).
+// jd: julian day (UT1).
 //
 // lon: longitude (west).
-func TransitTime(jd float64, p int, lon float64) (float64, error) {
-	l, err := EclipticLongitude(jd, p)
+func (s Sky) Transit(jd float64, lon float64) (Root, error) {
+	b := s.Body
+	bp, err := b.parameters()
 	if err != nil {
-		return 0, err
+		return Root{}, err
 	}
-
-	var J0, J1, J2, J3 float64
-	var J_transit float64
-
-	switch p {
-	case 0:
-		J3 = 360.0 / (T1Mercury - M1Mercury)
-		J0 = (M0Mercury + PMercury + 180 - T0Mercury) * (J3 / 360.0)
-		J1 = C1Mercury * (J3 / 360.0)
-		J2 = 0
-	case 1:
-		J3 = 360.0 / (T1Venus - M1Venus)
-		J0 = (M0Venus + PVenus + 180 - T0Venus) * (J3 / 360.0)
-		J1 = C1Venus * (J3 / 360.0)
-		J2 = -0.0304 * (J3 / 360.0)
-	case 2:
-		J3 = 360.0 / (T1Earth - M1Earth)
-		J0 = (M0Earth + PEarth + 180 - T0Earth) * (J3 / 360.0)
-		J1 = C1Earth * (J3 / 360.0)
-		J2 = -2.4657 * (J3 / 360.0)
-	case 3:
-		J3 = 360.0 / (T1Mars - M1Mars)
-		J0 = (M0Mars + PMars + 180 - T0Mars) * (J3 / 360.0)
-		J1 = C1Mars * (J3 / 360.0)
-		J2 = -2.8608 * (J3 / 360.0)
-	case 4:
-		J3 = 360.0 / (T1Jupiter - M1Jupiter)
-		J0 = (M0Jupiter + PJupiter + 180 - T0Jupiter) * (J3 / 360.0)
-		J1 = C1Jupiter * (J3 / 360.0)
-		J2 = -2.8608 * (J3 / 360.0)
-	case 5:
-		J3 = 360.0 / (T1Saturn - M1Saturn)
-		J0 = (M0Saturn + PSaturn + 180 - T0Saturn) * (J3 / 360.0)
-		J1 = C1Saturn * (J3 / 360.0)
-		J2 = -2.8608 * (J3 / 360.0)
-	default:
-		return 0, ErrInvalidEnum
-	}
-
-	M, err := MeanAnomaly(jd, p)
-	if err != nil {
-		return 0, err
+	if err := checkLongitude(lon); err != nil {
+		return Root{}, err
 	}
 
+	// The orbit runs on dynamical time, the rotation on universal time.
+	M := bp.meanAnomaly(s.dynamicalTime(jd))
+	l := bp.eclipticLongitude(M, bp.equationOfCenter(M))
+
+	J3 := bp.solarDay()
+	J0 := (bp.M0 + bp.P + 180 - bp.T0) * (J3 / 360.0)
+	J1 := bp.C[0] * (J3 / 360.0)
+	J2 := bp.A2 * (J3 / 360.0)
+
 	var n float64
 	n_x := (jd-julian.J2000-J0)/J3 - lon/360.0
 	if n_x-math.Floor(n_x) >= 0.5 {
@@ -602,198 +637,223 @@ func TransitTime(jd float64, p int, lon float64) (float64, error) {
 		n = math.Floor(n_x)
 	}
 
-	J_transit = jd + J3*(n-n_x) + J1*math.Sin(M*RAD) + J2*math.Sin(2*l*RAD)
+	J_transit := jd + J3*(n-n_x) + J1*math.Sin(M*RAD) + J2*math.Sin(2*l*RAD)
 
-	// Refine the transit time until it holds steady up to 6 decimal places.
-	J_str := fmt.Sprintf("%.6f", J_transit)
-	for {
-		H, err := HourAngle(J_transit, p, lon)
-		if err != nil {
-			return 0, err
-		}
+	// Refine the transit time until the hour angle is zero. The hour angle
+	// grows by 360° in a solar day, and runs from -90° to 90° over the half day
+	// around the transit, which brackets it should the refinement fail.
+	H := func(J float64) (float64, error) {
+		H, err := s.HourAngle(J, lon)
+		return normalize180(H), err
+	}
 
-		J_transit -= (H / 360.0) * J3
-		if J_str == fmt.Sprintf("%.6f", J_transit) {
-			// Hour angle is sufficiently close to zero at this point.
-			break
-		}
+	r, err := s.Solver.Newton(H, J_transit, 360.0/J3)
+	if err == nil {
+		return r, nil
+	}
 
-		J_str = fmt.Sprintf("%.6f", J_transit)
+	w := math.Abs(J3) / 4
+
+	return s.Solver.Brent(H, J_transit-w, J_transit+w)
+}
+
+// Returns the hour angle at which the sun is at altitude h_0, for a sun at
+// declination d. ErrPolarNight or ErrMidnightSun is returned when the sun stays
+// below or above that altitude all day.
+func crossingHourAngle(h_0, d, lat float64) (float64, error) {
+	cosH := (math.Sin(h_0*RAD) - math.Sin(lat*RAD)*math.Sin(d*RAD)) /
+		(math.Cos(lat*RAD) * math.Cos(d*RAD))
+
+	switch {
+	case cosH > 1:
+		return 0, ErrPolarNight
+	case cosH < -1:
+		return 0, ErrMidnightSun
+	case math.IsNaN(cosH):
+		return 0, ErrNoConvergence
 	}
 
-	return J_transit, err
+	return math.Acos(cosH) * DEG, nil
 }
 
-// Sunrise time (J_rise) is the moment at which the top of the solar disk
-// touches the horizon in the morning, taking into account refraction and solar
-// disk size.
+// Direction in which the sun crosses an altitude.
+type Direction int
+
+const (
+	Rising Direction = iota
+	Setting
+)
+
+// Crossing time is the moment around the transit nearest to the julian day at
+// which the sun crosses altitude h_0, going up or down. ErrPolarNight or
+// ErrMidnightSun is returned when the sun stays below or above that altitude
+// all day.
+//
+// jd: julian day (UT1).
 //
-// jd: julian day.
+// h_0: altitude of the center of the solar disk.
 //
-// p: enum of the planet (see README).
+// dir: direction of the crossing.
 //
 // lat: latitude (north)
 //
 // lon: longitude (west).
-func SunriseTime(jd float64, p int, lat, lon float64) (float64, error) {
-	d, err := Declination(jd, p)
+func (s Sky) CrossingTime(
+	jd float64, h_0 float64, dir Direction, lat, lon float64,
+) (float64, error) {
+	r, err := s.Crossing(jd, h_0, dir, lat, lon)
+
+	return r.JD, err
+}
+
+// Crossing is the crossing time together with the diagnostics of its
+// refinement by the solver of the sky.
+//
+// jd: julian day (UT1).
+//
+// h_0: altitude of the center of the solar disk.
+//
+// dir: direction of the crossing.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) Crossing(
+	jd float64, h_0 float64, dir Direction, lat, lon float64,
+) (Root, error) {
+	return s.crossingTime(
+		jd, func(float64) float64 { return h_0 }, dir, lat, lon,
+	)
+}
+
+// Returns the crossing time for an altitude h_0 that depends on the azimuth of
+// the sun, as it does behind an uneven horizon.
+func (s Sky) crossingTime(
+	jd float64, h_0 func(A float64) float64, dir Direction, lat, lon float64,
+) (Root, error) {
+	if err := checkLatitude(lat); err != nil {
+		return Root{}, err
+	}
+
+	J, err := s.TransitTime(jd, lon)
 	if err != nil {
-		return 0, err
+		return Root{}, err
 	}
 
-	var H_rise, J3 float64
-
-	switch p {
-	case 0:
-		H_rise = math.Acos(
-			(math.Sin(h_0Mercury*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Mercury - M1Mercury)
-	case 1:
-		H_rise = math.Acos(
-			(math.Sin(h_0Venus*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Venus - M1Venus)
-	case 2:
-		H_rise = math.Acos(
-			(math.Sin(h_0Earth*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Earth - M1Earth)
-	case 3:
-		H_rise = math.Acos(
-			(math.Sin(h_0Mars*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Mars - M1Mars)
-	case 4:
-		H_rise = math.Acos(
-			(math.Sin(h_0Jupiter*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Jupiter - M1Jupiter)
-	case 5:
-		H_rise = math.Acos(
-			(math.Sin(h_0Saturn*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Saturn - M1Saturn)
-	default:
-		return 0, ErrInvalidEnum
-	}
-
-	J_transit, err := TransitTime(jd, p, lon)
+	H, d, err := s.hourAngle(J, lat, lon)
 	if err != nil {
-		return 0, err
+		return Root{}, err
 	}
 
-	J_rise := J_transit - (H_rise/360.0)*J3
+	return s.crossingFrom(J, H, d, h_0, dir, lat, lon)
+}
+
+// Returns the crossing time next to the transit at julian day J, at which the
+// sun is at hour angle H and declination d, so that the crossings of a day can
+// share one transit and one position of the sun for their first guess.
+func (s Sky) crossingFrom(
+	J, H, d float64, h_0 func(A float64) float64, dir Direction, lat, lon float64,
+) (Root, error) {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return Root{}, err
+	}
 
-	// Refine the sunrise time until it holds steady up to 6 decimal places.
-	str := fmt.Sprintf("%.6f", J_rise)
-	for {
-		H, err := HourAngle(J_rise, p, lon)
+	J3 := bp.solarDay()
+
+	// The hour angle is negative before the transit and positive after it,
+	// except on bodies with retrograde rotation, where the sun rises in the
+	// west.
+	side := 1.0
+	if dir == Rising {
+		side = -1.0
+	}
+	sign := side
+	if J3 < 0 {
+		sign = -sign
+	}
+
+	// The declination changes between the transit and the crossing, so the
+	// target hour angle is recalculated at every step. Behind an uneven
+	// horizon the sun can stay below the horizon in one direction and cross it
+	// in another, so any failure falls back to the bracketing below.
+	H_0, err := crossingHourAngle(h_0(azimuth(H, d, lat)), d, lat)
+	if err == nil {
+		f := func(J float64) (float64, error) {
+			H, d, err := s.hourAngle(J, lat, lon)
+			if err != nil {
+				return 0, err
+			}
+			H = normalize180(H)
+
+			H_0, err := crossingHourAngle(h_0(azimuth(H, d, lat)), d, lat)
+
+			return H - sign*H_0, err
+		}
+
+		r, err := s.Solver.Newton(f, J+side*(H_0/360.0)*math.Abs(J3), 360.0/J3)
+		if err == nil {
+			return r, nil
+		}
+	}
+
+	// The sun is highest at the transit and lowest half a solar day away, so
+	// the altitude brackets the crossing in between.
+	g := func(J float64) (float64, error) {
+		H, d, err := s.hourAngle(J, lat, lon)
 		if err != nil {
 			return 0, err
 		}
-		H = normalize180(H)
 
-		J_rise -= ((H + H_rise) / 360.0) * J3
-		if str == fmt.Sprintf("%.6f", J_rise) {
-			break
+		return altitude(H, d, lat) - h_0(azimuth(H, d, lat)), nil
+	}
+
+	r, err := s.Solver.Brent(g, J, J+side*math.Abs(J3)/2)
+	if errors.Is(err, ErrNotBracketed) {
+		if g, _ := g(J); g < 0 {
+			return Root{}, ErrPolarNight
 		}
 
-		str = fmt.Sprintf("%.6f", J_rise)
+		return Root{}, ErrMidnightSun
 	}
 
-	return J_rise, err
+	return r, err
 }
 
-// Sunset time (J_set) is the moment at which the top of the solar disk touches
-// the horizon in the evening, taking into account refraction and solar disk
-// size.
-//
-// jd: julian day.
+// Sunrise time (J_rise) is the moment at which the top of the solar disk
+// touches the horizon in the morning, taking into account refraction and solar
+// disk size. ErrPolarNight or ErrMidnightSun is returned when the sun does not
+// rise on that day.
 //
-// p: enum of the planet (see README).
+// jd: julian day (UT1).
 //
 // lat: latitude (north)
 //
 // lon: longitude (west).
-func SunsetTime(jd float64, p int, lat, lon float64) (float64, error) {
-	d, err := Declination(jd, p)
+func (s Sky) SunriseTime(jd float64, lat, lon float64) (float64, error) {
+	bp, err := s.Body.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	var H_set, J3 float64
-
-	switch p {
-	case 0:
-		H_set = math.Acos(
-			(math.Sin(h_0Mercury*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Mercury - M1Mercury)
-	case 1:
-		H_set = math.Acos(
-			(math.Sin(h_0Venus*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Venus - M1Venus)
-	case 2:
-		H_set = math.Acos(
-			(math.Sin(h_0Earth*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Earth - M1Earth)
-	case 3:
-		H_set = math.Acos(
-			(math.Sin(h_0Mars*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Mars - M1Mars)
-	case 4:
-		H_set = math.Acos(
-			(math.Sin(h_0Jupiter*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Jupiter - M1Jupiter)
-	case 5:
-		H_set = math.Acos(
-			(math.Sin(h_0Saturn*RAD)-math.Sin(lat*RAD)*math.Sin(d*RAD))/
-				math.Cos(lat*RAD)*math.Cos(d*RAD),
-		) * DEG
-		J3 = 360.0 / (T1Saturn - M1Saturn)
-	default:
-		return 0, ErrInvalidEnum
-	}
-
-	J_transit, err := TransitTime(jd, p, lon)
+	return s.CrossingTime(jd, bp.h_0, Rising, lat, lon)
+}
+
+// Sunset time (J_set) is the moment at which the top of the solar disk touches
+// the horizon in the evening, taking into account refraction and solar disk
+// size. ErrPolarNight or ErrMidnightSun is returned when the sun does not set on
+// that day.
+//
+// jd: julian day (UT1).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) SunsetTime(jd float64, lat, lon float64) (float64, error) {
+	bp, err := s.Body.parameters()
 	if err != nil {
 		return 0, err
 	}
 
-	J_set := J_transit + (H_set/360.0)*J3
-
-	// Refine the sunrise time until it holds steady up to 6 decimal places.
-	str := fmt.Sprintf("%.6f", J_set)
-	for {
-		H, err := HourAngle(J_set, p, lon)
-		if err != nil {
-			return 0, err
-		}
-		H = normalize180(H)
-
-		J_set -= ((H - H_set) / 360.0) * J3
-		if str == fmt.Sprintf("%.6f", J_set) {
-			break
-		}
-
-		str = fmt.Sprintf("%.6f", J_set)
-	}
-
-	return J_set, err
+	return s.CrossingTime(jd, bp.h_0, Setting, lat, lon)
 }
@@ -31,6 +31,8 @@ func TestMeanAnomaly(t *testing.T) {
 	}{
 		{"ForEarth", 2453097.0, 2, 87.18073456000002, nil},
 		{"ForEarth", 2453097.0, 3, 112.65309536000007, nil},
+		{"ForUranus", 2453097.0, 6, 159.25218368, nil},
+		{"ForPluto", 2453097.0, 8, 21.02792, nil},
 		{"InvalidPlanet", 2453097.0, 12, 0, ErrInvalidEnum},
 	}
 
@@ -53,6 +55,7 @@ func TestObliquityEcliptic(t *testing.T) {
 	}{
 		{"ForEarth", 2, EEarth, nil},
 		{"ForMars", 3, EMars, nil},
+		{"ForUranus", 6, EUranus, nil},
 		{"InvalidPlanet", 12, 0, ErrInvalidEnum},
 	}
 
@@ -65,6 +68,30 @@ func TestObliquityEcliptic(t *testing.T) {
 	}
 }
 
+// Pole tests.
+func TestPole(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Body
+		a, d float64
+		err  error
+	}{
+		{"ForEarth", Earth, 0, 90, nil},
+		{"ForMars", Mars, PoleAMars, PoleDMars, nil},
+		{"ForUranus", Uranus, PoleAUranus, PoleDUranus, nil},
+		{"InvalidPlanet", Body(12), 0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, d, err := tt.b.Pole()
+			assert.Equal(t, tt.a, a)
+			assert.Equal(t, tt.d, d)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
 // PerihelionLongitude tests.
 func TestPerihelionLongitude(t *testing.T) {
 	tests := []struct {
@@ -188,8 +215,8 @@ func TestDeclination(t *testing.T) {
 		d    float64
 		err  error
 	}{
-		{"ForEarth", 2453097.0, 2, 4.740184662324431, nil},
-		{"ForMars", 2453097.0, 3, 5.496702418591823, nil},
+		{"ForEarth", 2453097.0, 2, 4.756490696059791, nil},
+		{"ForMars", 2453097.0, 3, 5.522173328007986, nil},
 		{"InvalidPlanet", 2453097.0, 12, 0, ErrInvalidEnum},
 	}
 
@@ -214,6 +241,8 @@ func TestSiderealTime(t *testing.T) {
 	}{
 		{"ForEarth", 2453097.0, 2, -5.0, 14.834671999909915, nil},
 		{"ForMars", 2453097.0, 3, 184.6, 33.13916751998477, nil},
+		{"ForVenus", 2453097.0, 1, 20, 305.82232239999985, nil},
+		{"ForUranus", 2453097.0, 6, 20, 157.5050743999891, nil},
 		{"InvalidPlanet", 2453097.0, 12, 34.7, 0, ErrInvalidEnum},
 	}
 
@@ -236,8 +265,8 @@ func TestHourAngle(t *testing.T) {
 		H    float64
 		err  error
 	}{
-		{"ForEarth", 2453097.0, 2, -5.0, 3.76980128420956, nil},
-		{"ForMars", 2453097.0, 3, 184.6, 21.278579105151533, nil},
+		{"ForEarth", 2453097.0, 2, -5.0, 3.7691242370245917, nil},
+		{"ForMars", 2453097.0, 3, 184.6, 21.27825369463494, nil},
 		{"InvalidPlanet", 2453097.0, 12, 34.7, 0, ErrInvalidEnum},
 	}
 
@@ -261,8 +290,8 @@ func TestAzimuth(t *testing.T) {
 		A    float64
 		err  error
 	}{
-		{"ForEarth", 2453097.0, 2, 52.0, -5.0, 5.109917114922145, nil},
-		{"ForMars", 2453097.0, 3, -14.6, 184.6, 132.10875118644827, nil},
+		{"ForEarth", 2453097.0, 2, 52.0, -5.0, 5.110242429243137, nil},
+		{"ForMars", 2453097.0, 3, -14.6, 184.6, 132.14713857242586, nil},
 		{"InvalidPlanet", 2453097.0, 12, -45, 34.7, 0, ErrInvalidEnum},
 	}
 
@@ -286,8 +315,8 @@ func TestAltitude(t *testing.T) {
 		h    float64
 		err  error
 	}{
-		{"ForEarth", 2453097.0, 2, 52.0, -5.0, 42.63670285961314, nil},
-		{"ForMars", 2453097.0, 3, -14.6, 184.6, 60.861557344577825, nil},
+		{"ForEarth", 2453097.0, 2, 52.0, -5.0, 42.65330698047637, nil},
+		{"ForMars", 2453097.0, 3, -14.6, 184.6, 60.84403919714302, nil},
 		{"InvalidPlanet", 2453097.0, 12, -45, 34.7, 0, ErrInvalidEnum},
 	}
 
@@ -310,8 +339,12 @@ func TestTransit(t *testing.T) {
 		J_transit float64
 		err       error
 	}{
-		{"ForEarth", 2453097.0, 2, -5.0, 2.4530969895304884e+06, nil},
-		{"ForMars", 2453097.0, 3, 184.6, 2.453096939282806e+06, nil},
+		{"ForEarth", 2453097.0, 2, -5.0, 2.4530969895323687e+06, nil},
+		{"ForMars", 2453097.0, 3, 184.6, 2.4530969392837347e+06, nil},
+		{"ForVenus", 2453097.0, 1, 20, 2.453108245310984e+06, nil},
+		{"ForUranus", 2453097.0, 6, 20, 2.453097317990083e+06, nil},
+		{"ForNeptune", 2453097.0, 7, 20, 2.453096694832327e+06, nil},
+		{"ForPluto", 2453097.0, 8, 20, 2.45309956390053e+06, nil},
 		{"InvalidPlanet", 2453097.0, 12, -45, 0, ErrInvalidEnum},
 	}
 
@@ -335,8 +368,14 @@ func TestSunrise(t *testing.T) {
 		J_rise float64
 		err    error
 	}{
-		{"ForEarth", 2453097.0, 2, 52, -5.0, 2.4530967190208086e+06, nil},
-		{"ForMars", 2453097.0, 3, -14.6, 184.6, 2.453096686034785e+06, nil},
+		{"ForEarth", 2453097.0, 2, 52, -5.0, 2.453096719211567e+06, nil},
+		{"ForMars", 2453097.0, 3, -14.6, 184.6, 2.453096686040873e+06, nil},
+		{"ForVenus", 2453097.0, 1, 0, 20, 2.453079148331164e+06, nil},
+		{"ForUranus", 2453097.0, 6, 0, 20, 2.45309713838679e+06, nil},
+		{"ForNeptune", 2453097.0, 7, 0, 20, 2.4530965269966424e+06, nil},
+		{"ForPluto", 2453097.0, 8, 0, 20, 2.4530979667532803e+06, nil},
+		{"PolarNight", 2453361.5, 2, 78.2, -15.6, 0, ErrPolarNight},
+		{"MidnightSun", 2453177.5, 2, 78.2, -15.6, 0, ErrMidnightSun},
 		{"InvalidPlanet", 2453097.0, 23, 12, -45, 0, ErrInvalidEnum},
 	}
 
@@ -360,8 +399,14 @@ func TestSunset(t *testing.T) {
 		J_set float64
 		err   error
 	}{
-		{"ForEarth", 2453097.0, 2, 52, -5.0, 2.4530972600402692e+06, nil},
-		{"ForMars", 2453097.0, 3, -14.6, 184.6, 2.453097192530769e+06, nil},
+		{"ForEarth", 2453097.0, 2, 52, -5.0, 2.453097260607122e+06, nil},
+		{"ForMars", 2453097.0, 3, -14.6, 184.6, 2.453097192450694e+06, nil},
+		{"ForVenus", 2453097.0, 1, 0, 20, 2.453137447608122e+06, nil},
+		{"ForUranus", 2453097.0, 6, 0, 20, 2.453097497593375e+06, nil},
+		{"ForNeptune", 2453097.0, 7, 0, 20, 2.4530968626680113e+06, nil},
+		{"ForPluto", 2453097.0, 8, 0, 20, 2.453101161047839e+06, nil},
+		{"PolarNight", 2453361.5, 2, 78.2, -15.6, 0, ErrPolarNight},
+		{"MidnightSun", 2453177.5, 2, 78.2, -15.6, 0, ErrMidnightSun},
 		{"InvalidPlanet", 2453097.0, 23, 12, -45, 0, ErrInvalidEnum},
 	}
 
@@ -373,3 +418,30 @@ func TestSunset(t *testing.T) {
 		})
 	}
 }
+
+// CrossingTime tests.
+func TestCrossingTime(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   float64
+		b    Body
+		h_0  float64
+		dir  Direction
+		lat  float64
+		lon  float64
+		J    float64
+		err  error
+	}{
+		{"ForEarthRising", 2453097.0, Earth, 30, Rising, 52, -5.0, 2.453096865107046e+06, nil},
+		{"TooHigh", 2453097.0, Earth, 60, Setting, 52, -5.0, 0, ErrPolarNight},
+		{"InvalidPlanet", 2453097.0, Body(23), 30, Rising, 52, -5.0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			J, err := tt.b.CrossingTime(tt.jd, tt.h_0, tt.dir, tt.lat, tt.lon)
+			assert.Equal(t, tt.J, J)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+// Equation of time (E) is the difference between apparent solar time, as read
+// from a sundial, and mean solar time (in degrees of hour angle). It is the
+// right ascension of the mean sun, moving uniformly along the equator, less
+// that of the true sun. On the Earth a degree is four minutes; on other bodies
+// it is 1/360 of their solar day.
+//
+// jd: julian day (UT1).
+func (b Body) EquationOfTime(jd float64) (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
+	}
+
+	jd = dynamicalTime(julian.TimeScales{}, jd)
+
+	M, err := b.MeanAnomaly(jd)
+	if err != nil {
+		return 0, err
+	}
+
+	a, err := b.RightAscension(jd)
+	if err != nil {
+		return 0, err
+	}
+
+	// Solar time runs backwards against the hour angle on bodies with
+	// retrograde rotation.
+	E := normalize180(math.Mod(bp.meanRightAscension(M)-a, 360.0))
+	if bp.solarDay() < 0 {
+		E = -E
+	}
+
+	return E, nil
+}
+
+// Returns the right ascension of the mean sun for mean anomaly M. On bodies
+// tilted beyond 90° the right ascension of the sun decreases along the orbit,
+// and so does that of the mean sun.
+func (bp *parameters) meanRightAscension(M float64) float64 {
+	L := M + bp.P + 180
+	if math.Cos(bp.E*RAD) < 0 {
+		return -L
+	}
+
+	return L
+}
+
+// Mean solar time is the local time (in hours, between 0 and 24) kept by the
+// mean sun, with noon when the mean sun transits. On the Earth it follows UT1;
+// on the other bodies an hour is 1/24 of their solar day.
+//
+// jd: julian day (UT1).
+//
+// lon: longitude (west).
+func (b Body) MeanSolarTime(jd float64, lon float64) (float64, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return 0, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, err
+	}
+
+	var hours float64
+	if b == Earth {
+		hours = (jd-math.Floor(jd))*24 + 12 - lon/15
+	} else {
+		theta, err := b.SiderealTime(jd, lon)
+		if err != nil {
+			return 0, err
+		}
+
+		M, err := b.MeanAnomaly(dynamicalTime(julian.TimeScales{}, jd))
+		if err != nil {
+			return 0, err
+		}
+
+		H := theta - bp.meanRightAscension(M)
+		if bp.solarDay() < 0 {
+			H = -H
+		}
+		hours = H/15 + 12
+	}
+
+	hours = math.Mod(hours, 24)
+	if hours < 0 {
+		hours += 24
+	}
+
+	return hours, nil
+}
+
+// Apparent solar time is the local time (in hours, between 0 and 24) shown by
+// a sundial: mean solar time corrected by the equation of time.
+//
+// jd: julian day (UT1).
+//
+// lon: longitude (west).
+func (b Body) ApparentSolarTime(jd float64, lon float64) (float64, error) {
+	hours, err := b.MeanSolarTime(jd, lon)
+	if err != nil {
+		return 0, err
+	}
+
+	E, err := b.EquationOfTime(jd)
+	if err != nil {
+		return 0, err
+	}
+
+	hours = math.Mod(hours+E/15, 24)
+	if hours < 0 {
+		hours += 24
+	}
+
+	return hours, nil
+}
+
+// Extremum is a turning point of the equation of time.
+type Extremum struct {
+	// Julian day (UT1) of the turning point.
+	JD float64
+
+	// Equation of time (in degrees) at the turning point.
+	E float64
+
+	// Whether the turning point is a maximum rather than a minimum.
+	Max bool
+}
+
+// Equation of time extremes are the turning points of the equation of time
+// between two julian days, in order. On the Earth there are two maxima and two
+// minima in a year. Each one is refined where the numeric slope of the curve
+// changes sign, and ErrNotBracketed is returned should it not.
+//
+// start: julian day (UT1) to search from.
+//
+// end: julian day (UT1) to search to.
+func (b Body) EquationOfTimeExtremes(start, end float64) ([]Extremum, error) {
+	bp, err := b.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sample the curve a few hundred times per orbit and refine every turning
+	// point found between three samples, where the slope changes sign.
+	step := 360.0 / bp.M1 / 400
+	E := func(jd float64) float64 {
+		e, _ := b.EquationOfTime(jd)
+		return e
+	}
+	slope := func(jd float64) (float64, error) {
+		return (E(jd+step/100) - E(jd-step/100)) / (step / 50), nil
+	}
+
+	var extremes []Extremum
+	prev, cur := E(start-step), E(start)
+	for jd := start; jd < end; jd += step {
+		next := E(jd + step)
+
+		isMax := cur > prev && cur >= next
+		isMin := cur < prev && cur <= next
+		if isMax || isMin {
+			r, err := Solver{}.Brent(slope, jd-step, jd+step)
+			if err != nil {
+				return nil, err
+			}
+			if r.JD >= start && r.JD < end {
+				extremes = append(extremes, Extremum{r.JD, E(r.JD), isMax})
+			}
+		}
+
+		prev, cur = cur, next
+	}
+
+	return extremes, nil
+}
+
+// Returns the offset (in seconds) of a clock at longitude lon running ahead of
+// UT by the given number of degrees.
+func solarOffset(lon, E float64) int {
+	return int(math.Round((E - NormalizeLongitude(lon)) * 240))
+}
+
+// LocalMeanTime returns t in local mean time (LMT) on the Earth, the time kept
+// by a clock following the mean sun at longitude lon.
+//
+// t: instant.
+//
+// lon: longitude (west).
+func LocalMeanTime(t time.Time, lon float64) (time.Time, error) {
+	if err := checkLongitude(lon); err != nil {
+		return time.Time{}, err
+	}
+
+	return t.In(time.FixedZone("LMT", solarOffset(lon, 0))), nil
+}
+
+// LocalApparentTime returns t in local apparent time (LAT) on the Earth, the
+// time shown by a sundial at longitude lon, to the second.
+//
+// t: instant.
+//
+// lon: longitude (west).
+func LocalApparentTime(t time.Time, lon float64) (time.Time, error) {
+	if err := checkLongitude(lon); err != nil {
+		return time.Time{}, err
+	}
+
+	E, err := Earth.EquationOfTime(julian.ToJulianDay(t.UTC()))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.In(time.FixedZone("LAT", solarOffset(lon, E))), nil
+}
+
+// FromLocalMeanTime returns the clock time in loc at which the local mean time
+// on the Earth at longitude lon reads the date and time of wall. The time zone
+// of wall is ignored.
+//
+// wall: local mean time.
+//
+// lon: longitude (west)
+//
+// loc: time zone of the clock.
+func FromLocalMeanTime(
+	wall time.Time, lon float64, loc *time.Location,
+) (time.Time, error) {
+	if err := checkLongitude(lon); err != nil {
+		return time.Time{}, err
+	}
+
+	return inZone(wall, solarOffset(lon, 0)).In(loc), nil
+}
+
+// FromLocalApparentTime returns the clock time in loc at which a sundial on the
+// Earth at longitude lon reads the date and time of wall. The time zone of wall
+// is ignored.
+//
+// wall: local apparent time.
+//
+// lon: longitude (west)
+//
+// loc: time zone of the clock.
+func FromLocalApparentTime(
+	wall time.Time, lon float64, loc *time.Location,
+) (time.Time, error) {
+	if err := checkLongitude(lon); err != nil {
+		return time.Time{}, err
+	}
+
+	// The equation of time depends on the instant being looked for, but
+	// changes by less than a minute a day, so the hour angle of the mean sun
+	// grows by 360° a day with it and Newton's method settles within a few
+	// steps of the local mean time.
+	W := julian.ToJulianDay(inZone(wall, 0))
+	f := func(jd float64) (float64, error) {
+		E, err := Earth.EquationOfTime(jd)
+
+		return 360*(jd-W) + E - NormalizeLongitude(lon), err
+	}
+
+	r, err := Solver{}.Newton(f, W+NormalizeLongitude(lon)/360, 360)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	E, err := Earth.EquationOfTime(r.JD)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return inZone(wall, solarOffset(lon, E)).In(loc), nil
+}
+
+// Returns the instant at which a clock offset from UTC by the given number of
+// seconds reads the date and time of wall.
+func inZone(wall time.Time, offset int) time.Time {
+	y, m, d := wall.Date()
+	h, min, s := wall.Clock()
+
+	return time.Date(
+		y, m, d, h, min, s, wall.Nanosecond(), time.FixedZone("", offset),
+	)
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/spa"
+	"github.com/stretchr/testify/assert"
+)
+
+// EquationOfTime tests.
+func TestEquationOfTime(t *testing.T) {
+	tests := []struct {
+		name string
+		jd   float64
+		b    Body
+		E    float64
+		err  error
+	}{
+		// Meeus, example 28.a: 13m42.6s on 1992 October 13.0 TD.
+		{"Meeus28a", 2448908.5 - 59.0/86400, Earth, 13.71 / 4, nil},
+		{"November", 2460617.5, Earth, 16.4210 / 4, nil},
+		{"February", 2460352.75, Earth, -14.2480 / 4, nil},
+		{"InvalidPlanet", 2460352.75, Body(23), 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			E, err := tt.b.EquationOfTime(tt.jd)
+			assert.Equal(t, tt.err, err)
+			assert.InDelta(t, tt.E, E, 5.0/240)
+		})
+	}
+}
+
+// EquationOfTimeExtremes tests: the turning points of 2024.
+func TestEquationOfTimeExtremes(t *testing.T) {
+	start := julian.ToJulianDay(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	expected := []struct {
+		month   time.Month
+		day     int
+		minutes float64
+		max     bool
+	}{
+		{time.February, 12, -14.25, false},
+		{time.May, 14, 3.68, true},
+		{time.July, 26, -6.51, false},
+		{time.November, 3, 16.42, true},
+	}
+
+	extremes, err := Earth.EquationOfTimeExtremes(start, start+366)
+	assert.NoError(t, err)
+	assert.Len(t, extremes, len(expected))
+
+	for i, e := range extremes {
+		_, month, day := julian.FromJulianDay(e.JD, time.UTC).Date()
+		assert.Equal(t, expected[i].month, month)
+		assert.Equal(t, expected[i].day, day)
+		assert.InDelta(t, expected[i].minutes, e.E*4, 0.01)
+		assert.Equal(t, expected[i].max, e.Max)
+	}
+
+	// The sundial on Mars runs up to 40 minutes of a sol fast and 51 minutes
+	// slow over a Martian year (Allison and McEwen, 2000).
+	extremes, err = Mars.EquationOfTimeExtremes(start, start+687)
+	assert.NoError(t, err)
+	assert.Len(t, extremes, 2)
+	assert.True(t, extremes[0].Max)
+	assert.InDelta(t, 40, extremes[0].E*4, 0.5)
+	assert.False(t, extremes[1].Max)
+	assert.InDelta(t, -51, extremes[1].E*4, 0.5)
+
+	_, err = Body(23).EquationOfTimeExtremes(start, start+366)
+	assert.ErrorIs(t, err, ErrInvalidEnum)
+}
+
+// ApparentSolarTime tests: a sundial follows the hour angle of SPA to within
+// seconds on the Earth and reads noon at the transit on other bodies.
+func TestApparentSolarTime(t *testing.T) {
+	for jd := 2460311.0; jd < 2460311.0+366; jd += 7.3 {
+		hours, err := Earth.ApparentSolarTime(jd, -5.0)
+		assert.NoError(t, err)
+
+		H := spa.SPA{}.Calculate(jd, 52, -5.0).H
+		expected := math.Mod(12+H/15, 24)
+		diff := math.Mod(hours-expected+36, 24) - 12
+		assert.InDelta(t, 0, diff*3600, 10)
+	}
+
+	for _, b := range []Body{Mercury, Venus, Mars, Jupiter, Uranus, Pluto} {
+		J, _ := b.TransitTime(2451545.0, -5.0)
+		hours, err := b.ApparentSolarTime(J, -5.0)
+		assert.NoError(t, err)
+		assert.InDelta(t, 12, hours, 1e-6, "%v", b)
+	}
+
+	hours, err := Earth.MeanSolarTime(2451545.0, -15.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 13.0, hours, 1e-9)
+
+	_, err = Earth.MeanSolarTime(2451545.0, math.NaN())
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
+
+// Local solar time tests: conversions between clock time in New York, local
+// mean time and local apparent time.
+func TestLocalSolarTime(t *testing.T) {
+	ny, _ := time.LoadLocation("America/New_York")
+	clock := time.Date(2024, 11, 3, 12, 0, 0, 0, ny)
+
+	lmt, err := LocalMeanTime(clock, 74.006)
+	assert.NoError(t, err)
+	assert.Equal(t, "12:03:59", lmt.Format(time.TimeOnly))
+
+	lat, err := LocalApparentTime(clock, 74.006)
+	assert.NoError(t, err)
+	assert.Equal(t, "12:20:24", lat.Format(time.TimeOnly))
+
+	actual, err := FromLocalMeanTime(lmt, 74.006, ny)
+	assert.NoError(t, err)
+	assert.True(t, clock.Equal(actual))
+	assert.Equal(t, ny, actual.Location())
+
+	actual, err = FromLocalApparentTime(lat, 74.006, ny)
+	assert.NoError(t, err)
+	assert.True(t, clock.Equal(actual))
+
+	// Noon on the sundial.
+	noon := time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC)
+	actual, err = FromLocalApparentTime(noon, 74.006, ny)
+	assert.NoError(t, err)
+	assert.Equal(t, "11:39:36", actual.Format(time.TimeOnly))
+
+	_, err = LocalApparentTime(clock, math.Inf(1))
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
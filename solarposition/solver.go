@@ -0,0 +1,192 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"time"
+)
+
+// Solver controls the refinement of event times. The zero Solver uses the
+// defaults.
+type Solver struct {
+	// MaxIterations is the limit on refinement steps, 50 by default. Newton's
+	// method and the bracketing fallback each get that many.
+	MaxIterations int
+
+	// Tolerance is the precision of event times, 10 ms by default.
+	Tolerance time.Duration
+}
+
+const (
+	// Default tolerance of event times.
+	defaultTolerance = 10 * time.Millisecond
+
+	// Machine epsilon of float64.
+	epsilon = 0x1p-52
+)
+
+// Root is an event time together with how it was found.
+type Root struct {
+	// Julian day (UT1) of the event.
+	JD float64
+
+	// Iterations is the number of refinement steps taken.
+	Iterations int
+
+	// Residual is the value of the function at JD (in degrees of hour angle
+	// or altitude), which is zero at the event.
+	Residual float64
+}
+
+// Returns the iteration limit and the tolerance (in days) of the solver.
+func (v Solver) limits() (int, float64) {
+	n, tol := v.MaxIterations, v.Tolerance
+	if n <= 0 {
+		n = maxIterations
+	}
+	if tol <= 0 {
+		tol = defaultTolerance
+	}
+
+	return n, tol.Seconds() / 86400
+}
+
+// Newton refines a julian day towards a root of f, stepping by f(x)/slope for
+// an estimate of the derivative of f. It converges quickly from a good first
+// guess, and fails with ErrNoConvergence when the steps do not shrink below the
+// tolerance within the iteration limit. Errors from f are returned as is.
+//
+// f: function of the julian day.
+//
+// x: first guess.
+//
+// slope: derivative of f (per day).
+func (v Solver) Newton(
+	f func(x float64) (float64, error), x, slope float64,
+) (Root, error) {
+	n, tol := v.limits()
+
+	for i := 1; i <= n; i++ {
+		fx, err := f(x)
+		if err != nil {
+			return Root{}, err
+		}
+
+		step := fx / slope
+		x -= step
+		if math.Abs(step) < tol {
+			fx, err := f(x)
+			if err != nil {
+				return Root{}, err
+			}
+
+			return Root{x, i, fx}, nil
+		}
+	}
+
+	return Root{}, ErrNoConvergence
+}
+
+// Brent finds a root of f between two julian days at which f has opposite
+// signs, combining bisection with secant and inverse quadratic steps (Brent's
+// method). Given such a bracket it converges for a continuous f, within the
+// iteration limit of the solver; ErrNotBracketed is returned when the signs
+// are the same. Errors from f are returned as is.
+//
+// f: function of the julian day.
+//
+// a, b: julian days bracketing the root.
+func (v Solver) Brent(
+	f func(x float64) (float64, error), a, b float64,
+) (Root, error) {
+	n, tol := v.limits()
+
+	fa, err := f(a)
+	if err != nil {
+		return Root{}, err
+	}
+	fb, err := f(b)
+	if err != nil {
+		return Root{}, err
+	}
+	if fa*fb > 0 {
+		return Root{}, ErrNotBracketed
+	}
+
+	c, fc := b, fb
+	var d, e float64
+	for i := 1; i <= n; i++ {
+		if fb*fc > 0 {
+			// The root lies between b and the previous a.
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		// Julian days are large, so the tolerance cannot go below the
+		// spacing of floating point numbers around them.
+		tol1 := 2*epsilon*math.Abs(b) + tol/2
+		m := (c - b) / 2
+		if math.Abs(m) <= tol1 || fb == 0 {
+			return Root{b, i, fb}, nil
+		}
+
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			// Try interpolating, secant or inverse quadratic.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, m)
+		}
+
+		fb, err = f(b)
+		if err != nil {
+			return Root{}, err
+		}
+	}
+
+	return Root{}, ErrNoConvergence
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Newton tests.
+func TestNewton(t *testing.T) {
+	f := func(x float64) (float64, error) { return x*x - 2, nil }
+
+	r, err := Solver{}.Newton(f, 1.5, 2*math.Sqrt2)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Sqrt2, r.JD, 1e-7)
+	assert.Greater(t, r.Iterations, 1)
+	fx, _ := f(r.JD)
+	assert.Equal(t, fx, r.Residual)
+
+	// A step that overshoots by twice the distance to the root never settles.
+	g := func(x float64) (float64, error) { return math.Copysign(1, x), nil }
+	_, err = Solver{}.Newton(g, 0.5, 1)
+	assert.ErrorIs(t, err, ErrNoConvergence)
+}
+
+// Brent tests.
+func TestBrent(t *testing.T) {
+	f := func(x float64) (float64, error) { return math.Cos(x), nil }
+
+	r, err := Solver{}.Brent(f, 0, 3)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Pi/2, r.JD, 1e-7)
+	assert.InDelta(t, 0, r.Residual, 1e-7)
+
+	_, err = Solver{}.Brent(f, 0, 1)
+	assert.ErrorIs(t, err, ErrNotBracketed)
+
+	_, err = Solver{MaxIterations: 2}.Brent(f, 0, 3)
+	assert.ErrorIs(t, err, ErrNoConvergence)
+
+	// The tolerance bounds the error of the root.
+	r, err = Solver{Tolerance: time.Hour}.Brent(f, 0, 3)
+	assert.NoError(t, err)
+	assert.InDelta(t, math.Pi/2, r.JD, 1.0/24)
+}
+
+// Solver tests: the event times report how they were refined and honour the
+// tolerance of the sky.
+func TestSolverEvents(t *testing.T) {
+	r, err := Earth.With(Analytic).Transit(2453097.0, -5.0)
+	assert.NoError(t, err)
+	assert.Greater(t, r.Iterations, 0)
+	assert.InDelta(t, 0, r.Residual, 1e-3)
+
+	J_transit, _ := Earth.TransitTime(2453097.0, -5.0)
+	assert.Equal(t, J_transit, r.JD)
+
+	coarse := Sky{Body: Earth, Solver: Solver{Tolerance: time.Minute}}
+	c, err := coarse.Crossing(2453097.0, h_0Earth, Rising, 52, -5.0)
+	assert.NoError(t, err)
+	J_rise, _ := Earth.SunriseTime(2453097.0, 52, -5.0)
+	assert.InDelta(t, J_rise, c.JD, 1.0/1440)
+	assert.LessOrEqual(t, c.Iterations, 2)
+
+	limited := Sky{Body: Earth, Solver: Solver{MaxIterations: 1}}
+	_, err = limited.Crossing(2453097.0, h_0Earth, Rising, 52, -5.0)
+	assert.ErrorIs(t, err, ErrNoConvergence)
+}
+
+// Solver tests: in December a ridge 20° high in the east hides the sun until
+// it passes the end of the ridge, at 20° east of south. Newton's method finds
+// no crossing behind the ridge; bracketing finds the sun coming out.
+func TestSolverRidge(t *testing.T) {
+	ridge := func(A float64) float64 {
+		if A < -20 {
+			return 20
+		}
+		return 0
+	}
+	o := Observer{52, -5.0, 0, 1010, 10, Bennett, ridge}
+
+	J_rise, err := Earth.SunriseTimeAt(2460665.0, o)
+	assert.NoError(t, err)
+	A, _ := Earth.Azimuth(J_rise, 52, -5.0)
+	assert.InDelta(t, -20, A, 1e-3)
+}
+
+// Solver tests: a sun racing backwards along the equator turns the hour angle
+// 1.9 times as fast as a solar day, which makes Newton's method overshoot back
+// and forth; the transit is then bracketed and found with Brent's method.
+func TestSolverFallback(t *testing.T) {
+	racing := EphemerisFunc(func(b Body, jd float64) (float64, float64, error) {
+		return math.Mod(-0.9*T1Earth*(jd-2453097.0), 360), 0, nil
+	})
+	s := Earth.With(racing)
+
+	r, err := s.Transit(2453097.0, -5.0)
+	assert.NoError(t, err)
+
+	H, err := s.HourAngle(r.JD, -5.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, normalize180(H), 1e-3)
+	assert.Less(t, r.Iterations, maxIterations)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+// Twilight is the period during which the sun is below the horizon but still
+// lights the sky, classified by how far below the horizon the sun is.
+type Twilight int
+
+const (
+	Civil Twilight = iota
+	Nautical
+	Astronomical
+
+	// NoTwilight marks the events that belong to no twilight: the rises,
+	// sets and transits found by Sky.Events. It has no altitude.
+	NoTwilight
+)
+
+const (
+	// Altitudes of the center of the solar disk bounding each twilight.
+	h_Civil        = -6.0
+	h_Nautical     = -12.0
+	h_Astronomical = -18.0
+)
+
+// Altitude returns the altitude of the center of the solar disk at which the
+// twilight begins in the morning and ends in the evening.
+func (t Twilight) Altitude() (float64, error) {
+	switch t {
+	case Civil:
+		return h_Civil, nil
+	case Nautical:
+		return h_Nautical, nil
+	case Astronomical:
+		return h_Astronomical, nil
+	default:
+		return 0, ErrInvalidTwilight
+	}
+}
+
+// String returns the name of the twilight.
+func (t Twilight) String() string {
+	switch t {
+	case Civil:
+		return "civil"
+	case Nautical:
+		return "nautical"
+	case Astronomical:
+		return "astronomical"
+	case NoTwilight:
+		return "none"
+	default:
+		return "invalid"
+	}
+}
+
+// Dawn time (J_dawn) is the moment at which the twilight begins in the morning.
+// ErrPolarNight or ErrMidnightSun is returned when the sun stays below or above
+// the twilight altitude all day.
+//
+// jd: julian day (UT1).
+//
+// t: kind of twilight.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) DawnTime(jd float64, t Twilight, lat, lon float64) (float64, error) {
+	h, err := t.Altitude()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.CrossingTime(jd, h, Rising, lat, lon)
+}
+
+// Dusk time (J_dusk) is the moment at which the twilight ends in the evening.
+// ErrPolarNight or ErrMidnightSun is returned when the sun stays below or above
+// the twilight altitude all day.
+//
+// jd: julian day (UT1).
+//
+// t: kind of twilight.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s Sky) DuskTime(jd float64, t Twilight, lat, lon float64) (float64, error) {
+	h, err := t.Altitude()
+	if err != nil {
+		return 0, err
+	}
+
+	return s.CrossingTime(jd, h, Setting, lat, lon)
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Twilight altitude tests.
+func TestTwilightAltitude(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Twilight
+		h    float64
+		err  error
+	}{
+		{"ForCivil", Civil, -6, nil},
+		{"ForNautical", Nautical, -12, nil},
+		{"ForAstronomical", Astronomical, -18, nil},
+		{"NoTwilight", NoTwilight, 0, ErrInvalidTwilight},
+		{"InvalidTwilight", Twilight(7), 0, ErrInvalidTwilight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := tt.t.Altitude()
+			assert.Equal(t, tt.h, h)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// Dawn tests.
+func TestDawn(t *testing.T) {
+	tests := []struct {
+		name   string
+		jd     float64
+		b      Body
+		t      Twilight
+		lat    float64
+		lon    float64
+		J_dawn float64
+		err    error
+	}{
+		{"ForCivil", 2453097.0, Earth, Civil, 52, -5.0, 2.4530966953852475e+06, nil},
+		{"ForNautical", 2453097.0, Earth, Nautical, 52, -5.0, 2.453096666657663e+06, nil},
+		{"ForAstronomical", 2453097.0, Earth, Astronomical, 52, -5.0, 2.453096635809534e+06, nil},
+		{"WhiteNight", 2453177.5, Earth, Astronomical, 52, -5.0, 0, ErrMidnightSun},
+		{"InvalidTwilight", 2453097.0, Earth, Twilight(7), 52, -5.0, 0, ErrInvalidTwilight},
+		{"InvalidPlanet", 2453097.0, Body(23), Civil, 52, -5.0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			J_dawn, err := tt.b.DawnTime(tt.jd, tt.t, tt.lat, tt.lon)
+			assert.Equal(t, tt.J_dawn, J_dawn)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+// Dusk tests.
+func TestDusk(t *testing.T) {
+	tests := []struct {
+		name   string
+		jd     float64
+		b      Body
+		t      Twilight
+		lat    float64
+		lon    float64
+		J_dusk float64
+		err    error
+	}{
+		{"ForCivil", 2453097.0, Earth, Civil, 52, -5.0, 2.4530972845340604e+06, nil},
+		{"ForNautical", 2453097.0, Earth, Nautical, 52, -5.0, 2.453097313424724e+06, nil},
+		{"ForAstronomical", 2453097.0, Earth, Astronomical, 52, -5.0, 2.4530973445284655e+06, nil},
+		{"WhiteNight", 2453177.5, Earth, Astronomical, 52, -5.0, 0, ErrMidnightSun},
+		{"InvalidTwilight", 2453097.0, Earth, Twilight(7), 52, -5.0, 0, ErrInvalidTwilight},
+		{"InvalidPlanet", 2453097.0, Body(23), Civil, 52, -5.0, 0, ErrInvalidEnum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			J_dusk, err := tt.b.DuskTime(tt.jd, tt.t, tt.lat, tt.lon)
+			assert.Equal(t, tt.J_dusk, J_dusk)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+)
+
+// Band is a range of altitudes (in degrees) of the center of the solar disk,
+// from High down to Low.
+type Band struct {
+	High, Low float64
+}
+
+var (
+	// GoldenHour is the warm light of a low sun, from 6° above the horizon
+	// to 4° below it.
+	GoldenHour = Band{6, -4}
+
+	// BlueHour is the deep blue light of the early civil twilight, from 4° to
+	// 6° below the horizon.
+	BlueHour = Band{-4, -6}
+)
+
+// Interval is a span of clock time.
+type Interval struct {
+	Start, End time.Time
+}
+
+// Window returns the intervals on the civil date in the time zone of loc
+// during which the sun passes through the band, rising in the morning and
+// setting in the evening. Only the year, month and day of date are used. Like
+// the twilights, the band holds geometric altitudes of the center of the solar
+// disk above the astronomical horizon, so it takes no elevation, refraction or
+// horizon profile of an observer. When the sun does not reach the top of the
+// band the two windows meet at the transit, and when it does not sink below the
+// bottom of the band they open and close at the lower culminations either side
+// of the transit, which may fall on the neighbouring dates. ErrPolarNight is
+// returned when the sun stays below the bottom of the band all day, and
+// ErrNoEvent when a crossing falls on a neighbouring date.
+//
+// date: civil date.
+//
+// loc: time zone of the observer.
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+//
+// b: band of altitudes.
+func (s Sky) Window(
+	date time.Time, loc *time.Location, lat, lon float64, b Band,
+) (Interval, Interval, error) {
+	if !(b.High > b.Low) {
+		return Interval{}, Interval{}, ErrInvalidBand
+	}
+	if err := checkLatitude(lat); err != nil {
+		return Interval{}, Interval{}, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return Interval{}, Interval{}, err
+	}
+
+	crossing := func(h float64, dir Direction) func(float64) (float64, error) {
+		return func(jd float64) (float64, error) {
+			J, err := s.CrossingTime(jd, h, dir, lat, lon)
+			if errors.Is(err, ErrPolarNight) && h == b.High {
+				return s.TransitTime(jd, lon)
+			}
+
+			return J, err
+		}
+	}
+
+	var morning, evening Interval
+	var err error
+	for _, e := range []struct {
+		t   *time.Time
+		h   float64
+		dir Direction
+	}{
+		{&morning.Start, b.Low, Rising},
+		{&morning.End, b.High, Rising},
+		{&evening.Start, b.High, Setting},
+		{&evening.End, b.Low, Setting},
+	} {
+		*e.t, err = eventOnDate(date, loc, crossing(e.h, e.dir))
+		if errors.Is(err, ErrMidnightSun) && e.h == b.Low {
+			*e.t, err = s.lowerCulmination(date, loc, lon, e.dir)
+		}
+		if err != nil {
+			return Interval{}, Interval{}, err
+		}
+	}
+
+	return morning, evening, nil
+}
+
+// Returns the lower culmination before the transit on the civil date, when
+// rising, or after it, when setting: the sun is lowest half a solar day from
+// the transit, where the hour angle is 180°.
+//
+// date: civil date.
+//
+// loc: time zone of the observer.
+//
+// lon: longitude (west).
+//
+// dir: side of the transit.
+func (s Sky) lowerCulmination(
+	date time.Time, loc *time.Location, lon float64, dir Direction,
+) (time.Time, error) {
+	bp, err := s.Body.parameters()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	transit, err := eventOnDate(date, loc, func(jd float64) (float64, error) {
+		return s.TransitTime(jd, lon)
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	J3 := bp.solarDay()
+	w := math.Abs(J3) / 2
+	if dir == Rising {
+		w = -w
+	}
+	J := julian.ToJulianDay(transit.UTC()) + w
+
+	H := func(J float64) (float64, error) {
+		H, err := s.HourAngle(J, lon)
+		return normalize180(H - 180), err
+	}
+
+	r, err := s.Solver.Newton(H, J, 360.0/J3)
+	if err != nil {
+		r, err = s.Solver.Brent(H, J-math.Abs(J3)/4, J+math.Abs(J3)/4)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return julian.FromJulianDay(r.JD, loc), nil
+}
+
+// Window is Sky.Window on the analytic ephemeris.
+func (b Body) Window(
+	date time.Time, loc *time.Location, lat, lon float64, band Band,
+) (Interval, Interval, error) {
+	return b.With(Analytic).Window(date, loc, lat, lon, band)
+}
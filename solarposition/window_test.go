@@ -0,0 +1,110 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package solarposition
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/stretchr/testify/assert"
+)
+
+// Window tests.
+func TestWindow(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+	helsinki, _ := time.LoadLocation("Europe/Helsinki")
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		loc     *time.Location
+		lat     float64
+		lon     float64
+		band    Band
+		morning [2]string
+		evening [2]string
+		err     error
+	}{
+		{"GoldenHour", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), london, 51.5, 0.1278,
+			GoldenHour, [2]string{"04:15", "05:37"}, [2]string{"20:27", "21:50"}, nil},
+		{"BlueHour", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), london, 51.5, 0.1278,
+			BlueHour, [2]string{"03:55", "04:15"}, [2]string{"21:50", "22:09"}, nil},
+		{"CustomBand", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), london, 51.5, 0.1278,
+			Band{10, 6}, [2]string{"05:37", "06:06"}, [2]string{"19:58", "20:27"}, nil},
+		{"LowSun", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), oslo, 69.65, -18.96,
+			GoldenHour, [2]string{"08:34", "11:58"}, [2]string{"11:58", "15:22"}, nil},
+		{"MidnightSun", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), helsinki, 65.01, -25.47,
+			GoldenHour, [2]string{"01:20", "04:34"}, [2]string{"22:05", "01:20"}, nil},
+		{"PolarNight", time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC), oslo, 80, -18.96,
+			BlueHour, [2]string{}, [2]string{}, ErrPolarNight},
+		{"InvalidBand", time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC), london, 51.5, 0.1278,
+			Band{-6, -4}, [2]string{}, [2]string{}, ErrInvalidBand},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			morning, evening, err := Earth.Window(tt.date, tt.loc, tt.lat, tt.lon, tt.band)
+			assert.Equal(t, tt.err, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, tt.morning, [2]string{morning.Start.Format("15:04"), morning.End.Format("15:04")})
+			assert.Equal(t, tt.evening, [2]string{evening.Start.Format("15:04"), evening.End.Format("15:04")})
+			assert.Equal(t, tt.loc, morning.Start.Location())
+		})
+	}
+}
+
+// Window tests: when the sun stays above the bottom of the band, the windows
+// open and close at the lower culminations either side of the transit.
+func TestWindowLowerCulmination(t *testing.T) {
+	helsinki, _ := time.LoadLocation("Europe/Helsinki")
+	date := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	morning, evening, err := Earth.Window(date, helsinki, 65.01, -25.47, GoldenHour)
+	assert.NoError(t, err)
+	assert.Equal(t, 21, morning.Start.Day())
+	assert.Equal(t, 22, evening.End.Day())
+
+	for _, tm := range []time.Time{morning.Start, evening.End} {
+		jd := julian.ToJulianDay(tm.UTC())
+		H, _ := Earth.HourAngle(jd, -25.47)
+		assert.InDelta(t, 180, H, 0.01)
+		h, _ := Earth.Altitude(jd, 65.01, -25.47)
+		assert.Greater(t, h, GoldenHour.Low)
+	}
+}
+
+// Window tests: the blue hour ends as the golden hour begins.
+func TestWindowAdjacent(t *testing.T) {
+	date := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	golden, _, err := Earth.Window(date, time.UTC, 52, -5.0, GoldenHour)
+	assert.NoError(t, err)
+	blue, _, err := Earth.Window(date, time.UTC, 52, -5.0, BlueHour)
+	assert.NoError(t, err)
+	assert.Equal(t, blue.End, golden.Start)
+
+	J_rise, _ := Earth.SunriseTime(2460390.0, 52, -5.0)
+	assert.True(t, golden.Start.Before(julian.FromJulianDay(J_rise, time.UTC)))
+	assert.True(t, golden.End.After(julian.FromJulianDay(J_rise, time.UTC)))
+
+	_, _, err = Earth.Window(date, time.UTC, -95, 0, GoldenHour)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	_, _, err = Earth.Window(date, time.UTC, 52, math.NaN(), GoldenHour)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+}
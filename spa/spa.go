@@ -0,0 +1,218 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spa implements the Solar Position Algorithm of the National
+// Renewable Energy Laboratory (Reda and Andreas, 2008), which calculates the
+// topocentric position of the sun seen from the Earth to ±0.0003° between the
+// years -2000 and 6000.
+package spa
+
+import (
+	"math"
+	"time"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/nutation"
+	"github.com/codymj/celestia/sidereal"
+	"github.com/codymj/celestia/vsop87"
+)
+
+const (
+	RAD = math.Pi / 180
+	DEG = 180 / math.Pi
+
+	// Radius of the solar disk (in degrees).
+	SunRadius = 0.26667
+
+	// Equatorial radius of the Earth (in meters).
+	EarthRadius = 6378140.0
+)
+
+// SPA holds the observer conditions of the algorithm. The zero value is an
+// observer at sea level without atmosphere; New returns standard conditions.
+type SPA struct {
+	// ΔT = TT - UT1 (in seconds). Zero uses julian.DeltaT for the date.
+	DeltaT float64
+
+	// Elevation above sea level (in meters).
+	Elevation float64
+
+	// Annual average local pressure (in millibars).
+	Pressure float64
+
+	// Annual average local temperature (in degrees Celsius).
+	Temperature float64
+
+	// Atmospheric refraction at sunrise and sunset (in degrees). Refraction is
+	// only applied while the sun is above -(SunRadius + Refraction).
+	Refraction float64
+}
+
+// Result holds the values calculated by the algorithm, in degrees unless
+// noted otherwise.
+type Result struct {
+	// Julian day (UT1) and julian ephemeris day (TT).
+	JD, JDE float64
+
+	// Heliocentric longitude, latitude and radius vector (in AU) of the Earth.
+	L, B, R float64
+
+	// Nutation in longitude and obliquity, and true obliquity of the ecliptic.
+	DeltaPsi, DeltaEpsilon, Epsilon float64
+
+	// Apparent geocentric longitude, right ascension and declination of the
+	// sun.
+	Lambda, Alpha, Delta float64
+
+	// Apparent sidereal time at Greenwich and observer local hour angle.
+	Nu, H float64
+
+	// Topocentric right ascension, declination and local hour angle.
+	AlphaPrime, DeltaPrime, HPrime float64
+
+	// Topocentric elevation angle without and with refraction correction.
+	E0, E float64
+
+	// Topocentric zenith angle.
+	Zenith float64
+
+	// Topocentric azimuth measured eastward from north (navigators) and
+	// westward from south (astronomers).
+	Azimuth, AzimuthAstro float64
+}
+
+// New returns the algorithm for standard conditions: sea level, 1010
+// millibars, 10 °C and 0.5667° of refraction at sunrise and sunset.
+func New() SPA {
+	return SPA{
+		Pressure:    1010,
+		Temperature: 10,
+		Refraction:  0.5667,
+	}
+}
+
+// Normalizes angles to be between 0 degrees and 360 degrees.
+func normalize360(angle float64) float64 {
+	angle = math.Mod(angle, 360.0)
+	if angle < 0 {
+		angle += 360.0
+	}
+
+	return angle
+}
+
+// Calculate runs the algorithm.
+//
+// jd: julian day (UT1).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s SPA) Calculate(jd float64, lat, lon float64) Result {
+	r := Result{JD: jd}
+
+	dt := s.DeltaT
+	if dt == 0 {
+		dt = julian.DeltaT(julian.FromJulianDay(jd, time.UTC))
+	}
+	r.JDE = jd + dt/julian.SecondsPerDay
+
+	// Geocentric position of the sun.
+	r.L, r.B, r.R = vsop87.Earth.Position(r.JDE)
+	r.DeltaPsi, r.DeltaEpsilon = nutation.Nutation(r.JDE)
+	r.Epsilon = nutation.MeanObliquity(r.JDE) + r.DeltaEpsilon
+
+	theta := normalize360(r.L + 180)
+	beta := -r.B
+	r.Lambda = theta + r.DeltaPsi - 20.4898/(3600*r.R)
+
+	l, e, b := r.Lambda*RAD, r.Epsilon*RAD, beta*RAD
+	r.Alpha = normalize360(math.Atan2(
+		math.Sin(l)*math.Cos(e)-math.Tan(b)*math.Sin(e), math.Cos(l),
+	) * DEG)
+	r.Delta = math.Asin(
+		math.Sin(b)*math.Cos(e)+math.Cos(b)*math.Sin(e)*math.Sin(l),
+	) * DEG
+
+	r.Nu = sidereal.GMST1982(jd) + r.DeltaPsi*math.Cos(e)
+	r.H = normalize360(r.Nu - lon - r.Alpha)
+
+	// Parallax of the sun for the observer.
+	xi := 8.794 / (3600 * r.R) * RAD
+	phi := lat * RAD
+	u := math.Atan(0.99664719 * math.Tan(phi))
+	x := math.Cos(u) + s.Elevation/EarthRadius*math.Cos(phi)
+	y := 0.99664719*math.Sin(u) + s.Elevation/EarthRadius*math.Sin(phi)
+
+	H, d := r.H*RAD, r.Delta*RAD
+	dAlpha := math.Atan2(
+		-x*math.Sin(xi)*math.Sin(H), math.Cos(d)-x*math.Sin(xi)*math.Cos(H),
+	)
+	r.AlphaPrime = r.Alpha + dAlpha*DEG
+	r.DeltaPrime = math.Atan2(
+		(math.Sin(d)-y*math.Sin(xi))*math.Cos(dAlpha),
+		math.Cos(d)-x*math.Sin(xi)*math.Cos(H),
+	) * DEG
+	r.HPrime = r.H - dAlpha*DEG
+
+	// Topocentric horizontal coordinates.
+	Hp, dp := r.HPrime*RAD, r.DeltaPrime*RAD
+	r.E0 = math.Asin(
+		math.Sin(phi)*math.Sin(dp)+math.Cos(phi)*math.Cos(dp)*math.Cos(Hp),
+	) * DEG
+
+	r.E = r.E0
+	if r.E0 >= -(SunRadius + s.Refraction) {
+		r.E += s.Pressure / 1010 * 283 / (273 + s.Temperature) *
+			1.02 / (60 * math.Tan((r.E0+10.3/(r.E0+5.11))*RAD))
+	}
+	r.Zenith = 90 - r.E
+
+	r.AzimuthAstro = math.Atan2(
+		math.Sin(Hp), math.Cos(Hp)*math.Sin(phi)-math.Tan(dp)*math.Cos(phi),
+	) * DEG
+	r.Azimuth = normalize360(r.AzimuthAstro + 180)
+
+	return r
+}
+
+// Horizontal calculates the azimuth, measured from the south and between -180°
+// and 180° like solarposition.Azimuth, and the topocentric altitude of the sun
+// corrected for refraction.
+//
+// jd: julian day (UT1).
+//
+// lat: latitude (north)
+//
+// lon: longitude (west).
+func (s SPA) Horizontal(jd float64, lat, lon float64) (float64, float64, error) {
+	r := s.Calculate(jd, lat, lon)
+
+	return r.AzimuthAstro, r.E, nil
+}
+
+// Incidence calculates the angle between the direction of the sun and the
+// normal of a surface (in degrees).
+//
+// slope: tilt of the surface from the horizontal.
+//
+// rotation: azimuth of the surface, measured from the south and positive
+// towards the west.
+func (r Result) Incidence(slope, rotation float64) float64 {
+	z, w, g := r.Zenith*RAD, slope*RAD, (r.AzimuthAstro-rotation)*RAD
+
+	return math.Acos(
+		math.Cos(z)*math.Cos(w)+math.Sin(w)*math.Sin(z)*math.Cos(g),
+	) * DEG
+}
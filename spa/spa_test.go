@@ -0,0 +1,88 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Calculate tests, against the example of the NREL SPA report: 2003-10-17
+// 12:30:30 at -7h, Golden (Colorado), 1830.14 m, 820 mbar, 11 °C, ΔT 67 s.
+func TestCalculate(t *testing.T) {
+	s := SPA{
+		DeltaT:      67,
+		Elevation:   1830.14,
+		Pressure:    820,
+		Temperature: 11,
+		Refraction:  0.5667,
+	}
+	r := s.Calculate(2452930.3128472222, 39.742476, 105.1786)
+
+	tests := []struct {
+		name     string
+		expected float64
+		actual   float64
+		delta    float64
+	}{
+		{"L", 24.0182616917, r.L, 1e-8},
+		{"B", -0.0001011219, r.B, 1e-10},
+		{"R", 0.9965422974, r.R, 1e-10},
+		{"DeltaPsi", -0.00399840, r.DeltaPsi, 1e-8},
+		{"DeltaEpsilon", 0.00166657, r.DeltaEpsilon, 1e-8},
+		{"Epsilon", 23.440465, r.Epsilon, 1e-6},
+		{"Lambda", 204.0085519281, r.Lambda, 1e-8},
+		{"Alpha", 202.22741, r.Alpha, 1e-5},
+		{"Delta", -9.31434, r.Delta, 1e-5},
+		{"H", 11.105902, r.H, 1e-6},
+		{"AlphaPrime", 202.22704, r.AlphaPrime, 1e-5},
+		{"DeltaPrime", -9.316179, r.DeltaPrime, 1e-6},
+		{"HPrime", 11.10627, r.HPrime, 1e-5},
+		{"Zenith", 50.11162, r.Zenith, 1e-5},
+		{"Azimuth", 194.34024, r.Azimuth, 1e-5},
+		{"AzimuthAstro", 14.34024, r.AzimuthAstro, 1e-5},
+		{"Incidence", 25.18700, r.Incidence(30, -10), 1e-5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.actual, tt.delta)
+		})
+	}
+}
+
+// Horizontal tests.
+func TestHorizontal(t *testing.T) {
+	s := New()
+	r := s.Calculate(2452930.3128472222, 39.742476, 105.1786)
+
+	A, h, err := s.Horizontal(2452930.3128472222, 39.742476, 105.1786)
+	assert.NoError(t, err)
+	assert.Equal(t, r.AzimuthAstro, A)
+	assert.Equal(t, 90-r.Zenith, h)
+}
+
+// Refraction tests: no correction without atmosphere, nor once the sun is
+// well below the horizon.
+func TestRefraction(t *testing.T) {
+	r := SPA{DeltaT: 67}.Calculate(2452930.3128472222, 39.742476, 105.1786)
+	assert.Equal(t, r.E0, r.E)
+
+	// Around local midnight.
+	r = New().Calculate(2452930.8, 39.742476, 105.1786)
+	assert.Less(t, r.E0, -SunRadius-0.5667)
+	assert.Equal(t, r.E0, r.E)
+}
@@ -0,0 +1,231 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spk
+
+import (
+	"math"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/nutation"
+	"github.com/codymj/celestia/solarposition"
+)
+
+const (
+	RAD = math.Pi / 180
+	DEG = 180 / math.Pi
+
+	// Speed of light (in km/s).
+	SpeedOfLight = 299792.458
+)
+
+var _ solarposition.Ephemeris = (*File)(nil)
+
+// Matrix is a rotation between reference frames.
+type Matrix [3][3]float64
+
+// Apply rotates the vector.
+func (m Matrix) Apply(v [3]float64) [3]float64 {
+	var r [3]float64
+	for i := range m {
+		r[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2]
+	}
+
+	return r
+}
+
+// Mul returns the rotation m after n.
+func (m Matrix) Mul(n Matrix) Matrix {
+	var r Matrix
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+
+	return r
+}
+
+// Precession returns the rotation from the mean equator and equinox of J2000
+// to those of the date (IAU 1976, Meeus chapter 21).
+//
+// jd: julian day (TT).
+func Precession(jd float64) Matrix {
+	t := (jd - julian.J2000) / 36525
+
+	zeta := (2306.2181*t + 0.30188*t*t + 0.017998*t*t*t) / 3600 * RAD
+	z := (2306.2181*t + 1.09468*t*t + 0.018203*t*t*t) / 3600 * RAD
+	theta := (2004.3109*t - 0.42665*t*t - 0.041833*t*t*t) / 3600 * RAD
+
+	cz, sz := math.Cos(zeta), math.Sin(zeta)
+	cZ, sZ := math.Cos(z), math.Sin(z)
+	ct, st := math.Cos(theta), math.Sin(theta)
+
+	return Matrix{
+		{cz*ct*cZ - sz*sZ, -sz*ct*cZ - cz*sZ, -st * cZ},
+		{cz*ct*sZ + sz*cZ, -sz*ct*sZ + cz*cZ, -st * sZ},
+		{cz * st, -sz * st, ct},
+	}
+}
+
+// Nutation returns the rotation from the mean equator and equinox of the date
+// to the true ones (IAU 1980).
+//
+// jd: julian day (TT).
+func Nutation(jd float64) Matrix {
+	dpsi, deps := nutation.Nutation(jd)
+	e0 := nutation.MeanObliquity(jd) * RAD
+	e := e0 + deps*RAD
+
+	cp, sp := math.Cos(dpsi*RAD), math.Sin(dpsi*RAD)
+	ce, se := math.Cos(e), math.Sin(e)
+	c0, s0 := math.Cos(e0), math.Sin(e0)
+
+	return Matrix{
+		{cp, -sp * c0, -sp * s0},
+		{sp * ce, cp*ce*c0 + se*s0, cp*ce*s0 - se*c0},
+		{sp * se, cp*se*c0 - ce*s0, cp*se*s0 + ce*c0},
+	}
+}
+
+// Returns the right ascension and declination (in degrees) of a vector.
+func spherical(v [3]float64) (float64, float64) {
+	r := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+
+	return math.Atan2(v[1], v[0]) * DEG, math.Asin(v[2]/r) * DEG
+}
+
+// Bodies the sun is seen from: the planets are seen from their barycenters.
+var targets = [...]int{
+	solarposition.Mercury: MercuryBarycenter,
+	solarposition.Venus:   VenusBarycenter,
+	solarposition.Earth:   Earth,
+	solarposition.Mars:    MarsBarycenter,
+	solarposition.Jupiter: JupiterBarycenter,
+	solarposition.Saturn:  SaturnBarycenter,
+	solarposition.Uranus:  UranusBarycenter,
+	solarposition.Neptune: NeptuneBarycenter,
+	solarposition.Pluto:   PlutoBarycenter,
+}
+
+// Returns the cross product of two vectors.
+func cross(u, v [3]float64) [3]float64 {
+	return [3]float64{
+		u[1]*v[2] - u[2]*v[1],
+		u[2]*v[0] - u[0]*v[2],
+		u[0]*v[1] - u[1]*v[0],
+	}
+}
+
+// Returns the dot product of two vectors.
+func dot(u, v [3]float64) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
+
+// Returns the vector scaled to a length of 1.
+func unit(v [3]float64) [3]float64 {
+	r := math.Sqrt(dot(v, v))
+
+	return [3]float64{v[0] / r, v[1] / r, v[2] / r}
+}
+
+// Equatorial calculates the apparent right ascension and declination of the
+// sun seen from a body (in degrees), corrected for light-time and aberration.
+// This makes the file a solarposition.Ephemeris.
+//
+// From the Earth they are referred to the true equator and equinox of the
+// date, with precession and nutation. From the other planets, seen from their
+// barycenters, they are referred to the equator of the planet, whose pole is
+// given by solarposition.Body.Pole, and to its equinox, where the sun crosses
+// that equator going north.
+//
+// jd: julian day (TT).
+func (f *File) Equatorial(b solarposition.Body, jd float64) (float64, float64, error) {
+	pa, pd, err := b.Pole()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tdb := jd + julian.TDBMinusTT(jd)/julian.SecondsPerDay
+	et := (tdb - julian.J2000) * julian.SecondsPerDay
+
+	p, err := f.apparentSun(targets[b], et)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if b == solarposition.Earth {
+		a, d := spherical(Nutation(jd).Mul(Precession(jd)).Apply(p))
+
+		return a, d, nil
+	}
+
+	// The orbit of the planet around the sun gives the plane the sun moves
+	// in, whose intersection with the equator is the line of the equinoxes.
+	s, sv, err := f.barycentric(Sun, et)
+	if err != nil {
+		return 0, 0, err
+	}
+	x, xv, err := f.barycentric(targets[b], et)
+	if err != nil {
+		return 0, 0, err
+	}
+	r := [3]float64{x[0] - s[0], x[1] - s[1], x[2] - s[2]}
+	v := [3]float64{xv[0] - sv[0], xv[1] - sv[1], xv[2] - sv[2]}
+
+	ca, sa := math.Cos(pa*RAD), math.Sin(pa*RAD)
+	cd, sd := math.Cos(pd*RAD), math.Sin(pd*RAD)
+	pole := [3]float64{cd * ca, cd * sa, sd}
+	equinox := unit(cross(pole, cross(r, v)))
+
+	a := math.Atan2(dot(p, cross(pole, equinox)), dot(p, equinox)) * DEG
+	d := math.Asin(dot(p, pole)) * DEG
+
+	return a, d, nil
+}
+
+// Returns the unit vector pointing to the apparent sun from a body in the
+// ICRF, corrected for light-time and aberration.
+//
+// et: TDB seconds past J2000.
+func (f *File) apparentSun(body int, et float64) ([3]float64, error) {
+	e, v, err := f.barycentric(body, et)
+	if err != nil {
+		return [3]float64{}, err
+	}
+
+	// The light seen at the body left the sun minutes or hours earlier.
+	var p [3]float64
+	tau := 0.0
+	for i := 0; i < 3; i++ {
+		s, _, err := f.barycentric(Sun, et-tau)
+		if err != nil {
+			return [3]float64{}, err
+		}
+
+		for c := 0; c < 3; c++ {
+			p[c] = s[c] - e[c]
+		}
+		tau = math.Sqrt(dot(p, p)) / SpeedOfLight
+	}
+
+	// Aberration of light due to the motion of the body, to first order.
+	r := tau * SpeedOfLight
+	cos := dot(p, v) / r / SpeedOfLight
+	for c := 0; c < 3; c++ {
+		p[c] = p[c]/r + v[c]/SpeedOfLight - cos*p[c]/r
+	}
+
+	return unit(p), nil
+}
@@ -0,0 +1,398 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spk reads JPL planetary ephemerides (DE440, DE441, ...) in the
+// binary SPK format of the NAIF SPICE toolkit. Segments of type 2 (Chebyshev
+// position) and type 3 (Chebyshev position and velocity) are supported, which
+// is what the JPL development ephemerides use.
+package spk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/codymj/celestia/julian"
+)
+
+// NAIF integer codes of the bodies of the JPL development ephemerides.
+const (
+	SolarSystemBarycenter = 0
+	MercuryBarycenter     = 1
+	VenusBarycenter       = 2
+	EarthMoonBarycenter   = 3
+	MarsBarycenter        = 4
+	JupiterBarycenter     = 5
+	SaturnBarycenter      = 6
+	UranusBarycenter      = 7
+	NeptuneBarycenter     = 8
+	PlutoBarycenter       = 9
+	Sun                   = 10
+	Mercury               = 199
+	Venus                 = 299
+	Moon                  = 301
+	Earth                 = 399
+)
+
+const (
+	// Length of a DAF record (in bytes).
+	recordLength = 1024
+
+	// Reference frame of the JPL development ephemerides (ICRF).
+	FrameJ2000 = 1
+)
+
+var (
+	ErrMalformed   = errors.New("malformed SPK file")
+	ErrUnsupported = errors.New("unsupported SPK format")
+	ErrNotCovered  = errors.New("no SPK segment covers the body at that time")
+)
+
+// Segment describes a segment of the file: the position of a target body
+// relative to a center body between two epochs.
+type Segment struct {
+	Name           string
+	Target, Center int
+	Frame, Type    int
+
+	// Epochs covered (in TDB seconds past J2000).
+	Start, End float64
+
+	// Addresses of the first and last double precision numbers (1-based).
+	begin, end int
+
+	// Directory of the Chebyshev records.
+	init, intlen float64
+	rsize, n     int
+}
+
+// File is an SPK file opened for reading. Records are read on demand, so the
+// file can be used concurrently and large ephemerides are not loaded into
+// memory.
+type File struct {
+	r        io.ReaderAt
+	closer   io.Closer
+	order    binary.ByteOrder
+	segments []Segment
+}
+
+// Open opens the SPK file at the path.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spk, err := Read(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	spk.closer = f
+
+	return spk, nil
+}
+
+// Read reads the segment directory of an SPK file.
+func Read(r io.ReaderAt) (*File, error) {
+	record := make([]byte, recordLength)
+	if _, err := r.ReadAt(record, 0); err != nil {
+		return nil, fmt.Errorf("%w: file record: %v", ErrMalformed, err)
+	}
+
+	id := string(record[:8])
+	if id != "DAF/SPK " && id != "NAIF/DAF" {
+		return nil, fmt.Errorf("%w: not an SPK file", ErrMalformed)
+	}
+
+	f := &File{r: r}
+	switch string(record[88:96]) {
+	case "LTL-IEEE":
+		f.order = binary.LittleEndian
+	case "BIG-IEEE":
+		f.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: binary format %q", ErrUnsupported, record[88:96])
+	}
+
+	nd := int(int32(f.order.Uint32(record[8:])))
+	ni := int(int32(f.order.Uint32(record[12:])))
+	if nd != 2 || ni != 6 {
+		return nil, fmt.Errorf("%w: summary format %d/%d", ErrMalformed, nd, ni)
+	}
+
+	// Summaries are 5 double words long: start, end, then six integers.
+	const ss = 5
+	next := int(int32(f.order.Uint32(record[76:])))
+
+	names := make([]byte, recordLength)
+	for seen := 0; next != 0; seen++ {
+		if seen > 1<<20 {
+			return nil, fmt.Errorf("%w: summary records loop", ErrMalformed)
+		}
+
+		if _, err := r.ReadAt(record, int64(next-1)*recordLength); err != nil {
+			return nil, fmt.Errorf("%w: summary record %d: %v", ErrMalformed, next, err)
+		}
+		if _, err := r.ReadAt(names, int64(next)*recordLength); err != nil {
+			return nil, fmt.Errorf("%w: name record %d: %v", ErrMalformed, next+1, err)
+		}
+
+		nsum := int(f.float(record[16:]))
+		if nsum < 0 || 3+nsum*ss > recordLength/8 {
+			return nil, fmt.Errorf("%w: %d summaries in record %d", ErrMalformed, nsum, next)
+		}
+
+		for i := 0; i < nsum; i++ {
+			b := record[(3+i*ss)*8:]
+			ints := func(k int) int { return int(int32(f.order.Uint32(b[16+4*k:]))) }
+
+			s := Segment{
+				Name:   strings.TrimRight(string(names[i*ss*8:(i+1)*ss*8]), " \x00"),
+				Start:  f.float(b),
+				End:    f.float(b[8:]),
+				Target: ints(0),
+				Center: ints(1),
+				Frame:  ints(2),
+				Type:   ints(3),
+				begin:  ints(4),
+				end:    ints(5),
+			}
+
+			if s.Type == 2 || s.Type == 3 {
+				if err := f.directory(&s); err != nil {
+					return nil, err
+				}
+			}
+
+			f.segments = append(f.segments, s)
+		}
+
+		next = int(f.float(record))
+	}
+
+	return f, nil
+}
+
+// Close closes the file opened by Open.
+func (f *File) Close() error {
+	if f.closer == nil {
+		return nil
+	}
+
+	return f.closer.Close()
+}
+
+// Segments returns the segments of the file, in file order.
+func (f *File) Segments() []Segment {
+	return append([]Segment(nil), f.segments...)
+}
+
+// Decodes a double precision number.
+func (f *File) float(b []byte) float64 {
+	return math.Float64frombits(f.order.Uint64(b))
+}
+
+// Reads n double precision numbers starting at the address (1-based).
+func (f *File) floats(address, n int) ([]float64, error) {
+	b := make([]byte, 8*n)
+	if _, err := f.r.ReadAt(b, int64(address-1)*8); err != nil {
+		return nil, fmt.Errorf("%w: address %d: %v", ErrMalformed, address, err)
+	}
+
+	v := make([]float64, n)
+	if err := binary.Read(bytes.NewReader(b), f.order, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Reads the directory at the end of a segment of type 2 or 3: initial epoch,
+// interval length, record size and number of records.
+func (f *File) directory(s *Segment) error {
+	d, err := f.floats(s.end-3, 4)
+	if err != nil {
+		return err
+	}
+
+	s.init, s.intlen, s.rsize, s.n = d[0], d[1], int(d[2]), int(d[3])
+
+	components := 3
+	if s.Type == 3 {
+		components = 6
+	}
+
+	if s.intlen <= 0 || s.n <= 0 || s.rsize < 2+components ||
+		(s.rsize-2)%components != 0 || s.begin+s.rsize*s.n-1 > s.end-4 {
+		return fmt.Errorf("%w: segment %d/%d directory", ErrMalformed, s.Target, s.Center)
+	}
+
+	return nil
+}
+
+// Evaluates the segment at the epoch: position (in km) and velocity (in km/s).
+func (f *File) state(s *Segment, et float64) ([3]float64, [3]float64, error) {
+	var pos, vel [3]float64
+
+	i := int(math.Floor((et - s.init) / s.intlen))
+	if i == s.n && et <= s.End {
+		i = s.n - 1
+	}
+	if i < 0 || i >= s.n {
+		return pos, vel, ErrNotCovered
+	}
+
+	rec, err := f.floats(s.begin+i*s.rsize, s.rsize)
+	if err != nil {
+		return pos, vel, err
+	}
+
+	mid, radius := rec[0], rec[1]
+	x := (et - mid) / radius
+
+	components := 3
+	if s.Type == 3 {
+		components = 6
+	}
+	degree := (s.rsize-2)/components - 1
+
+	// Chebyshev polynomials and their derivatives at x.
+	T := make([]float64, degree+1)
+	dT := make([]float64, degree+1)
+	T[0] = 1
+	if degree > 0 {
+		T[1], dT[1] = x, 1
+	}
+	for k := 2; k <= degree; k++ {
+		T[k] = 2*x*T[k-1] - T[k-2]
+		dT[k] = 2*T[k-1] + 2*x*dT[k-1] - dT[k-2]
+	}
+
+	for c := 0; c < 3; c++ {
+		coef := rec[2+c*(degree+1) : 2+(c+1)*(degree+1)]
+		for k, a := range coef {
+			pos[c] += a * T[k]
+			vel[c] += a * dT[k] / radius
+		}
+	}
+
+	if s.Type == 3 {
+		for c := 0; c < 3; c++ {
+			vel[c] = 0
+			coef := rec[2+(c+3)*(degree+1) : 2+(c+4)*(degree+1)]
+			for k, a := range coef {
+				vel[c] += a * T[k]
+			}
+		}
+	}
+
+	return pos, vel, nil
+}
+
+// Finds the segment of the body at the epoch. Like SPICE, later segments take
+// precedence over earlier ones.
+func (f *File) segment(target int, et float64) (*Segment, error) {
+	unsupported := false
+	for i := len(f.segments) - 1; i >= 0; i-- {
+		s := &f.segments[i]
+		if s.Target != target || et < s.Start || et > s.End {
+			continue
+		}
+
+		if s.Type != 2 && s.Type != 3 {
+			unsupported = true
+			continue
+		}
+
+		return s, nil
+	}
+
+	if unsupported {
+		return nil, fmt.Errorf("%w: segment type of body %d", ErrUnsupported, target)
+	}
+
+	return nil, fmt.Errorf("%w: body %d at %.1f s", ErrNotCovered, target, et)
+}
+
+// Returns the state of the body relative to the solar system barycenter,
+// following the chain of segment centers.
+func (f *File) barycentric(body int, et float64) ([3]float64, [3]float64, error) {
+	var pos, vel [3]float64
+
+	for depth := 0; body != SolarSystemBarycenter; depth++ {
+		if depth > 16 {
+			return pos, vel, fmt.Errorf("%w: segment centers loop", ErrMalformed)
+		}
+
+		s, err := f.segment(body, et)
+		if err != nil {
+			return pos, vel, err
+		}
+
+		p, v, err := f.state(s, et)
+		if err != nil {
+			return pos, vel, err
+		}
+
+		for c := 0; c < 3; c++ {
+			pos[c] += p[c]
+			vel[c] += v[c]
+		}
+
+		body = s.Center
+	}
+
+	return pos, vel, nil
+}
+
+// State calculates the position (in km) and velocity (in km/s) of the target
+// relative to the center, in the ICRF.
+//
+// jd: julian day (TDB).
+func (f *File) State(target, center int, jd float64) ([3]float64, [3]float64, error) {
+	et := (jd - julian.J2000) * julian.SecondsPerDay
+
+	tp, tv, err := f.barycentric(target, et)
+	if err != nil {
+		return tp, tv, err
+	}
+
+	cp, cv, err := f.barycentric(center, et)
+	if err != nil {
+		return cp, cv, err
+	}
+
+	for c := 0; c < 3; c++ {
+		tp[c] -= cp[c]
+		tv[c] -= cv[c]
+	}
+
+	return tp, tv, nil
+}
+
+// Position calculates the position (in km) of the target relative to the
+// center, in the ICRF.
+//
+// jd: julian day (TDB).
+func (f *File) Position(target, center int, jd float64) ([3]float64, error) {
+	p, _, err := f.State(target, center, jd)
+
+	return p, err
+}
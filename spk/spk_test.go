@@ -0,0 +1,234 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spk
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/codymj/celestia/solarposition"
+	"github.com/stretchr/testify/assert"
+)
+
+// The sample kernel covers 2024 with Keplerian orbits, see testdata/gen.go.
+const sample = "testdata/de-sample.bsp"
+
+// A JPL development ephemeris to test against, such as de440s.bsp, is read
+// from this variable when it is set.
+const kernel = "CELESTIA_DE_KERNEL"
+
+// Returns the length of a vector.
+func norm(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
+
+// Open tests.
+func TestOpen(t *testing.T) {
+	f, err := Open(sample)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	segments := f.Segments()
+	assert.Len(t, segments, 4)
+
+	tests := []struct {
+		name           string
+		target, center int
+		kind           int
+	}{
+		{"SUN", Sun, SolarSystemBarycenter, 2},
+		{"EARTH-MOON BARYCENTER", EarthMoonBarycenter, SolarSystemBarycenter, 2},
+		{"MARS BARYCENTER", MarsBarycenter, SolarSystemBarycenter, 2},
+		{"EARTH", Earth, EarthMoonBarycenter, 3},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := segments[i]
+			assert.Equal(t, tt.name, s.Name)
+			assert.Equal(t, tt.target, s.Target)
+			assert.Equal(t, tt.center, s.Center)
+			assert.Equal(t, FrameJ2000, s.Frame)
+			assert.Equal(t, tt.kind, s.Type)
+			assert.Equal(t, (2460310.5-2451545.0)*86400, s.Start)
+			assert.Equal(t, (2460676.5-2451545.0)*86400, s.End)
+		})
+	}
+}
+
+// State tests, against the orbits the sample kernel was generated from.
+func TestState(t *testing.T) {
+	f, err := Open(sample)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for jd := 2460310.5; jd <= 2460676.5; jd += 1.37 {
+		// Type 3: the Earth circles the Earth-Moon barycenter.
+		p, v, err := f.State(Earth, EarthMoonBarycenter, jd)
+		assert.NoError(t, err)
+		assert.InDelta(t, 4670, norm(p), 1e-6)
+		assert.InDelta(t, 4670*2*math.Pi/(27.321661*86400), norm(v), 1e-9)
+		assert.InDelta(t, 0, p[0]*v[0]+p[1]*v[1]+p[2]*v[2], 1e-6)
+
+		// Type 2: the sun circles the barycenter.
+		p, v, err = f.State(Sun, SolarSystemBarycenter, jd)
+		assert.NoError(t, err)
+		assert.InDelta(t, 700000, norm(p), 1e-3)
+		assert.InDelta(t, 700000*2*math.Pi/(4332.59*86400), norm(v), 1e-9)
+
+		// Chained through the barycenters, the Earth is an AU or so away.
+		p, err = f.Position(Earth, Sun, jd)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1, norm(p)/149597870.7, 0.02)
+	}
+
+	_, err = f.Position(Earth, Sun, 2460300.5)
+	assert.ErrorIs(t, err, ErrNotCovered)
+	_, err = f.Position(Moon, Earth, 2460400.5)
+	assert.ErrorIs(t, err, ErrNotCovered)
+}
+
+// Equatorial tests: the apparent sun of the sample kernel follows the VSOP87
+// model to the accuracy of the Keplerian orbits.
+func TestEquatorial(t *testing.T) {
+	f, err := Open(sample)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for jd := 2460311.0; jd < 2460676.0; jd += 3.7 {
+		a, d, err := f.Equatorial(solarposition.Earth, jd)
+		assert.NoError(t, err)
+
+		ea, ed, _ := solarposition.Earth.EquatorialCoordinates(jd, solarposition.VSOP87)
+		assert.InDelta(t, ea, a, 15.0/3600)
+		assert.InDelta(t, ed, d, 15.0/3600)
+	}
+
+	_, _, err = f.Equatorial(solarposition.Body(-1), 2460400.5)
+	assert.ErrorIs(t, err, solarposition.ErrInvalidEnum)
+	_, _, err = f.Equatorial(solarposition.Jupiter, 2460400.5)
+	assert.ErrorIs(t, err, ErrNotCovered)
+
+	// The kernel drives the higher layers.
+	sky := solarposition.Earth.With(f)
+	expected, _ := solarposition.Earth.With(solarposition.VSOP87).SunriseTime(2460400.5, 52, -5.0)
+	actual, err := sky.SunriseTime(2460400.5, 52, -5.0)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected, actual, 1.0/86400)
+}
+
+// Equatorial tests on Mars: the sun seen from the barycenter follows the
+// analytic model, and crosses the equator of Mars northward at the equinox of
+// 2024 November 12.
+func TestEquatorialMars(t *testing.T) {
+	f, err := Open(sample)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for jd := 2460311.0; jd < 2460676.0; jd += 3.7 {
+		a, d, err := f.Equatorial(solarposition.Mars, jd)
+		assert.NoError(t, err)
+
+		ea, ed, _ := solarposition.Mars.EquatorialCoordinates(jd, solarposition.Analytic)
+		assert.InDelta(t, 0, math.Remainder(a-ea, 360), 0.2)
+		assert.InDelta(t, ed, d, 0.1)
+	}
+
+	_, before, err := f.Equatorial(solarposition.Mars, 2460626.0)
+	assert.NoError(t, err)
+	_, after, err := f.Equatorial(solarposition.Mars, 2460628.0)
+	assert.NoError(t, err)
+	assert.Less(t, before, 0.0)
+	assert.Greater(t, after, 0.0)
+}
+
+// Equatorial tests against a JPL development ephemeris, skipped unless one is
+// given in CELESTIA_DE_KERNEL. The apparent sun follows the VSOP87 model to
+// the accuracy of the truncated series.
+func TestEquatorialKernel(t *testing.T) {
+	path := os.Getenv(kernel)
+	if path == "" {
+		t.Skip(kernel + " not set")
+	}
+
+	f, err := Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	for jd := 2451545.0; jd < 2469807.5; jd += 97.3 {
+		a, d, err := f.Equatorial(solarposition.Earth, jd)
+		assert.NoError(t, err)
+
+		ea, ed, _ := solarposition.Earth.EquatorialCoordinates(jd, solarposition.VSOP87)
+		assert.InDelta(t, 0, math.Remainder(a-ea, 360), 1.0/3600)
+		assert.InDelta(t, ed, d, 1.0/3600)
+	}
+}
+
+// Precession tests, against Meeus example 21.b (θ Persei to 2028 November
+// 13.19 TD).
+func TestPrecession(t *testing.T) {
+	a, d := 41.054063*RAD, 49.227750*RAD
+	v := [3]float64{math.Cos(d) * math.Cos(a), math.Cos(d) * math.Sin(a), math.Sin(d)}
+
+	a, d = spherical(Precession(2462088.69).Apply(v))
+	assert.InDelta(t, 41.547214, a, 1e-6)
+	assert.InDelta(t, 49.348483, d, 1e-6)
+}
+
+// Nutation tests: the rotation moves the equinox by the nutation in
+// longitude.
+func TestNutation(t *testing.T) {
+	n := Nutation(2446895.5)
+
+	// Meeus example 22.a: Δψ = -3.788″ and Δε = +9.443″.
+	a, d := spherical(n.Apply([3]float64{1, 0, 0}))
+	e := 23.440946 * RAD
+	assert.InDelta(t, -3.788/3600*math.Cos(e), a, 1e-6)
+	assert.InDelta(t, -3.788/3600*math.Sin(e), d, 1e-6)
+
+	// Rotations keep lengths.
+	assert.InDelta(t, 1, norm(n.Mul(Precession(2446895.5)).Apply([3]float64{0, 0.6, 0.8})), 1e-15)
+}
+
+// Read malformed input tests.
+func TestReadMalformed(t *testing.T) {
+	data, err := os.ReadFile(sample)
+	assert.NoError(t, err)
+
+	truncated := data[:2048]
+	vax := append([]byte(nil), data...)
+	copy(vax[88:], "VAX-GFLT")
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"Empty", nil, ErrMalformed},
+		{"NotSPK", bytes.Repeat([]byte{'x'}, 1024), ErrMalformed},
+		{"Truncated", truncated, ErrMalformed},
+		{"Format", vax, ErrUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+// Generates de-sample.bsp, a small SPK file in the layout of the JPL
+// development ephemerides for 2024, to test the reader without bundling a
+// 100 MB kernel. The heliocentric Earth-Moon barycenter and Mars barycenter
+// follow the Keplerian elements of Standish (Keplerian Elements for Approximate
+// Positions of the Major Planets, JPL), independent of the theories of the
+// module; the sun circles the solar system barycenter and the Earth circles the
+// Earth-Moon barycenter on made up orbits.
+//
+//	go run spk/testdata/gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"math"
+	"os"
+
+	"github.com/codymj/celestia/julian"
+	"github.com/codymj/celestia/spk"
+)
+
+const (
+	AU    = 149597870.7
+	start = 2460310.5
+	end   = 2460676.5
+
+	// Made up orbits of the sun and the Earth (in km and days).
+	sunRadius   = 700000.0
+	sunPeriod   = 4332.59
+	earthRadius = 4670.0
+	earthPeriod = 27.321661
+)
+
+// Returns a point on a circle of the equator, and its velocity per day.
+func circle(jd, radius, period, phase float64) ([3]float64, [3]float64) {
+	w := 2 * math.Pi / period
+	a := w*(jd-julian.J2000) + phase
+
+	return [3]float64{radius * math.Cos(a), radius * math.Sin(a), 0},
+		[3]float64{-radius * w * math.Sin(a), radius * w * math.Cos(a), 0}
+}
+
+// Keplerian elements and their rates per julian century: semi-major axis (in
+// AU), eccentricity, inclination, mean longitude, longitude of perihelion and
+// longitude of the ascending node (in degrees), referred to the ecliptic and
+// equinox of J2000.
+type elements [6][2]float64
+
+var (
+	emb = elements{
+		{1.00000261, 0.00000562}, {0.01671123, -0.00004392},
+		{-0.00001531, -0.01294668}, {100.46457166, 35999.37244981},
+		{102.93768193, 0.32327364}, {0, 0},
+	}
+	mars = elements{
+		{1.52371034, 0.00001847}, {0.09339410, 0.00007882},
+		{1.84969142, -0.00813131}, {-4.55343205, 19140.30268499},
+		{-23.94362959, 0.44441088}, {49.55953891, -0.29257343},
+	}
+)
+
+// Returns the heliocentric position on the orbit in the ICRF (in km).
+func (k elements) position(jd float64) [3]float64 {
+	T := (jd - julian.J2000) / 36525
+	var el [6]float64
+	for i, c := range k {
+		el[i] = c[0] + c[1]*T
+	}
+	a, e := el[0], el[1]
+	I, L, w, node := el[2]*math.Pi/180, el[3], el[4], el[5]
+
+	M := math.Mod(L-w, 360) * math.Pi / 180
+	E := M
+	for i := 0; i < 20; i++ {
+		E -= (E - e*math.Sin(E) - M) / (1 - e*math.Cos(E))
+	}
+
+	x := a * (math.Cos(E) - e)
+	y := a * math.Sqrt(1-e*e) * math.Sin(E)
+
+	o := (w - node) * math.Pi / 180
+	n := node * math.Pi / 180
+	co, so := math.Cos(o), math.Sin(o)
+	cn, sn := math.Cos(n), math.Sin(n)
+	ci, si := math.Cos(I), math.Sin(I)
+
+	ex := (co*cn-so*sn*ci)*x + (-so*cn-co*sn*ci)*y
+	ey := (co*sn+so*cn*ci)*x + (-so*sn+co*cn*ci)*y
+	ez := so*si*x + co*si*y
+
+	// Obliquity of the ecliptic at J2000.
+	eps := 23.43928 * math.Pi / 180
+
+	return [3]float64{
+		ex * AU,
+		(ey*math.Cos(eps) - ez*math.Sin(eps)) * AU,
+		(ey*math.Sin(eps) + ez*math.Cos(eps)) * AU,
+	}
+}
+
+// Returns the sum of two vectors.
+func add(u, v [3]float64) [3]float64 {
+	return [3]float64{u[0] + v[0], u[1] + v[1], u[2] + v[2]}
+}
+
+type segment struct {
+	name           string
+	target, center int
+	kind           int
+	days           float64
+	degree         int
+	state          func(jd float64) ([3]float64, [3]float64)
+}
+
+// Fits Chebyshev polynomials to the segment, one record per interval.
+func (s segment) data() []float64 {
+	components := 3
+	if s.kind == 3 {
+		components = 6
+	}
+
+	n := int(math.Round((end - start) / s.days))
+	rsize := 2 + components*(s.degree+1)
+	intlen := s.days * julian.SecondsPerDay
+
+	var data []float64
+	for i := 0; i < n; i++ {
+		a := start + float64(i)*s.days
+		mid := a + s.days/2
+		rec := make([]float64, rsize)
+		rec[0] = (mid - julian.J2000) * julian.SecondsPerDay
+		rec[1] = intlen / 2
+
+		N := s.degree + 1
+		for j := 0; j < N; j++ {
+			x := math.Cos(math.Pi * (float64(j) + 0.5) / float64(N))
+			p, v := s.state(mid + x*s.days/2)
+			f := append(p[:], v[0]/julian.SecondsPerDay, v[1]/julian.SecondsPerDay, v[2]/julian.SecondsPerDay)
+
+			for c := 0; c < components; c++ {
+				for k := 0; k < N; k++ {
+					w := 2 / float64(N) * math.Cos(math.Pi*float64(k)*(float64(j)+0.5)/float64(N))
+					if k == 0 {
+						w /= 2
+					}
+					rec[2+c*N+k] += w * f[c]
+				}
+			}
+		}
+
+		data = append(data, rec...)
+	}
+
+	return append(data, (start-julian.J2000)*julian.SecondsPerDay, intlen, float64(rsize), float64(n))
+}
+
+func main() {
+	segments := []segment{
+		{"SUN", spk.Sun, spk.SolarSystemBarycenter, 2, 366, 8,
+			func(jd float64) ([3]float64, [3]float64) {
+				return circle(jd, sunRadius, sunPeriod, 0.5)
+			}},
+		{"EARTH-MOON BARYCENTER", spk.EarthMoonBarycenter, spk.SolarSystemBarycenter, 2, 16, 13,
+			func(jd float64) ([3]float64, [3]float64) {
+				s, _ := circle(jd, sunRadius, sunPeriod, 0.5)
+
+				return add(s, emb.position(jd)), [3]float64{}
+			}},
+		{"MARS BARYCENTER", spk.MarsBarycenter, spk.SolarSystemBarycenter, 2, 16, 11,
+			func(jd float64) ([3]float64, [3]float64) {
+				s, _ := circle(jd, sunRadius, sunPeriod, 0.5)
+
+				return add(s, mars.position(jd)), [3]float64{}
+			}},
+		{"EARTH", spk.Earth, spk.EarthMoonBarycenter, 3, 8, 10,
+			func(jd float64) ([3]float64, [3]float64) {
+				return circle(jd, earthRadius, earthPeriod, 0)
+			}},
+	}
+
+	const word = 8
+	var file, summaries, names, data bytes.Buffer
+	le := binary.LittleEndian
+	address := 3*128 + 1
+
+	for _, s := range segments {
+		d := s.data()
+		binary.Write(&summaries, le, []float64{
+			(start - julian.J2000) * julian.SecondsPerDay,
+			(end - julian.J2000) * julian.SecondsPerDay,
+		})
+		binary.Write(&summaries, le, []int32{
+			int32(s.target), int32(s.center), spk.FrameJ2000, int32(s.kind),
+			int32(address), int32(address + len(d) - 1),
+		})
+
+		name := make([]byte, 5*word)
+		copy(name, s.name)
+		for i := len(s.name); i < len(name); i++ {
+			name[i] = ' '
+		}
+		names.Write(name)
+
+		binary.Write(&data, le, d)
+		address += len(d)
+	}
+
+	// File record.
+	record := make([]byte, 1024)
+	copy(record, "DAF/SPK ")
+	le.PutUint32(record[8:], 2)
+	le.PutUint32(record[12:], 6)
+	copy(record[16:76], "celestia test kernel                                        ")
+	le.PutUint32(record[76:], 2)
+	le.PutUint32(record[80:], 2)
+	le.PutUint32(record[84:], uint32(address))
+	copy(record[88:], "LTL-IEEE")
+	copy(record[699:], "FTPSTR:\r:\n:\r\n:\r\x00:\x81:\x10\xce:ENDFTP")
+	file.Write(record)
+
+	// Summary record: next, previous and number of summaries.
+	record = make([]byte, 1024)
+	binary.Write(bytes.NewBuffer(record[:0]), le, []float64{0, 0, float64(len(segments))})
+	copy(record[3*word:], summaries.Bytes())
+	file.Write(record)
+
+	record = bytes.Repeat([]byte{' '}, 1024)
+	copy(record, names.Bytes())
+	file.Write(record)
+
+	file.Write(data.Bytes())
+
+	if err := os.WriteFile("spk/testdata/de-sample.bsp", file.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vsop87 calculates heliocentric positions of the planets from the
+// VSOP87D planetary theory of Bretagnon and Francou: ecliptic longitude,
+// latitude and radius vector, referred to the mean ecliptic and equinox of the
+// date.
+//
+// The truncated series of Meeus (Astronomical Algorithms, appendix III) are
+// embedded for the Earth only, which is all the VSOP87 model of solarposition
+// uses; they are accurate to about one arcsecond between 2000 BCE and 6000 CE.
+// Complete theories for any planet can be read from the files distributed by
+// the IMCCE with Read, and give the sun seen from that planet through
+// solarposition.Theories.
+package vsop87
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/codymj/celestia/julian"
+)
+
+const (
+	RAD = math.Pi / 180
+	DEG = 180 / math.Pi
+
+	// Julian days in a julian millennium, the time unit of the series.
+	DaysPerMillennium = 365250
+)
+
+var ErrMalformed = errors.New("malformed VSOP87D file")
+
+// Term is a periodic term A cos(B + C t) of a series, t in julian millennia.
+type Term struct {
+	A, B, C float64
+}
+
+// Series holds the terms of one coordinate grouped by power of t: Series[n]
+// is multiplied by t^n.
+type Series [][]Term
+
+// Evaluates the series at t julian millennia from J2000.
+func (s Series) value(t float64) float64 {
+	v := 0.0
+	tn := 1.0
+	for _, terms := range s {
+		sum := 0.0
+		for _, term := range terms {
+			sum += term.A * math.Cos(term.B+term.C*t)
+		}
+		v += sum * tn
+		tn *= t
+	}
+
+	return v
+}
+
+// Theory holds the series of a planet for ecliptic longitude (L), latitude (B)
+// and radius vector (R), in radians and astronomical units.
+type Theory struct {
+	Name    string
+	L, B, R Series
+}
+
+// Position calculates the heliocentric ecliptic longitude (l) and latitude (b)
+// in degrees and the radius vector (r) in astronomical units, referred to the
+// mean ecliptic and equinox of the date.
+//
+// jde: julian ephemeris day (TT).
+func (th *Theory) Position(jde float64) (l, b, r float64) {
+	t := (jde - julian.J2000) / DaysPerMillennium
+
+	l = math.Mod(th.L.value(t)*DEG, 360.0)
+	if l < 0 {
+		l += 360.0
+	}
+
+	return l, th.B.value(t) * DEG, th.R.value(t)
+}
+
+// Transforms a table of Meeus, amplitudes in units of 1e-8, into a series.
+func meeus(table ...[][3]float64) Series {
+	s := make(Series, len(table))
+	for n, rows := range table {
+		s[n] = make([]Term, len(rows))
+		for i, row := range rows {
+			s[n][i] = Term{row[0] * 1e-8, row[1], row[2]}
+		}
+	}
+
+	return s
+}
+
+// Read parses a VSOP87D file as distributed by the IMCCE (VSOP87D.ear,
+// VSOP87D.mar, ...). Each block of terms starts with a header line naming the
+// variable (1: L, 2: B, 3: R) and the power of t; the last three fields of a
+// term line are its amplitude, phase and frequency.
+func Read(r io.Reader) (*Theory, error) {
+	th := &Theory{}
+	var s *Series
+	n := -1
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if fields[0] == "VSOP87" {
+			var err error
+			s, n, err = th.header(fields)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, line, err)
+			}
+
+			continue
+		}
+
+		if s == nil || len(fields) < 3 {
+			return nil, fmt.Errorf("%w: line %d: unexpected term", ErrMalformed, line)
+		}
+
+		var abc [3]float64
+		for i, f := range fields[len(fields)-3:] {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, line, err)
+			}
+			abc[i] = v
+		}
+
+		(*s)[n] = append((*s)[n], Term{abc[0], abc[1], abc[2]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if th.L == nil || th.B == nil || th.R == nil {
+		return nil, fmt.Errorf("%w: missing variables", ErrMalformed)
+	}
+
+	return th, nil
+}
+
+// Parses a block header such as "VSOP87 VERSION D4 EARTH VARIABLE 1 (LBR) *T**0
+// 559 TERMS ..." and returns the series and power the following terms belong
+// to.
+func (th *Theory) header(fields []string) (*Series, int, error) {
+	if len(fields) < 8 || fields[1] != "VERSION" || fields[4] != "VARIABLE" {
+		return nil, 0, errors.New("invalid header")
+	}
+
+	if !strings.HasPrefix(fields[2], "D") {
+		return nil, 0, fmt.Errorf("version %s is not VSOP87D", fields[2])
+	}
+
+	name := fields[3]
+	if th.Name == "" {
+		th.Name = name
+	} else if th.Name != name {
+		return nil, 0, fmt.Errorf("planet %s in a file of %s", name, th.Name)
+	}
+
+	var s *Series
+	switch fields[5] {
+	case "1":
+		s = &th.L
+	case "2":
+		s = &th.B
+	case "3":
+		s = &th.R
+	default:
+		return nil, 0, fmt.Errorf("invalid variable %s", fields[5])
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(fields[7], "*T**"))
+	if err != nil || n < 0 {
+		return nil, 0, fmt.Errorf("invalid power %s", fields[7])
+	}
+
+	for len(*s) <= n {
+		*s = append(*s, nil)
+	}
+
+	return s, n, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Cody Johnson
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vsop87
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Position tests, against Meeus example 25.b and the NREL SPA example.
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		theory  *Theory
+		jde     float64
+		l, b, r float64
+		delta   float64
+	}{
+		{"Meeus25b", Earth, 2448908.5, 19.907372, -0.000179, 0.99760775, 1e-6},
+		{"SPA", Earth, 2452930.312847222 + 67.0/86400, 24.0182616917, -0.0001011219, 0.9965422974, 1e-8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, b, r := tt.theory.Position(tt.jde)
+			assert.InDelta(t, tt.l, l, tt.delta)
+			assert.InDelta(t, tt.b, b, tt.delta)
+			assert.InDelta(t, tt.r, r, tt.delta)
+		})
+	}
+}
+
+// Read tests. The sample file holds the leading terms of the VSOP87D.ear file
+// distributed by the IMCCE.
+func TestRead(t *testing.T) {
+	f, err := os.Open("testdata/VSOP87D.sample")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	th, err := Read(f)
+	assert.NoError(t, err)
+	assert.Equal(t, "EARTH", th.Name)
+	assert.Len(t, th.L, 2)
+	assert.Len(t, th.L[0], 3)
+	assert.Equal(t, Term{0.03341656456, 4.66925680417, 6283.0758499914}, th.L[0][1])
+	assert.Len(t, th.B, 1)
+	assert.Len(t, th.R, 2)
+
+	// The leading terms alone are good to a few hundredths of a degree.
+	l, b, r := th.Position(2448908.5)
+	assert.InDelta(t, 19.907372, l, 0.05)
+	assert.InDelta(t, -0.000179, b, 0.0005)
+	assert.InDelta(t, 0.99760775, r, 0.0005)
+}
+
+// Read malformed input tests.
+func TestReadMalformed(t *testing.T) {
+	header := " VSOP87 VERSION D4    EARTH     VARIABLE %s (LBR)       *T**0      1 TERMS\n"
+	term := " 4310    1  0  0  0  0  0  0  0  0  0  0  0  0  0.00000000000     0.00000000000     1.75347045673 0.00000000000       0.00000000000\n"
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"TermBeforeHeader", term},
+		{"BadVersion", strings.Replace(strings.Replace(header, "D4", "A4", 1), "%s", "1", 1) + term},
+		{"BadVariable", strings.Replace(header, "%s", "4", 1) + term},
+		{"BadNumber", strings.Replace(header, "%s", "1", 1) + strings.Replace(term, "1.753", "1.7x3", 1)},
+		{"MissingVariables", strings.Replace(header, "%s", "1", 1) + term},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.file))
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}